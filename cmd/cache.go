@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"gojira/services/cache"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+)
+
+// cacheCmd representa o comando para gerenciar o cache de respostas
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Gerencia o cache de respostas da IA",
+	Long:  `Gerencia o cache local de respostas da IA. Prompts idênticos para o mesmo provedor e modelo reaproveitam a resposta em cache até que ela expire.`,
+}
+
+// cacheClearCmd representa o comando para limpar o cache
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove todas as respostas do cache",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		removed, err := cache.Clear()
+		if err != nil {
+			return err
+		}
+
+		output.Progress(i18n.T("cache.cleared", removed))
+
+		result := newResult(cmd, nil)
+		result.Data = map[string]int{"removed": removed}
+		return emitResult(result)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
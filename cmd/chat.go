@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"gojira/functions"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"os"
+	"strings"
+)
+
+// chatCmd representa o comando para conversar com a IA sobre o repositório
+var chatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Abre uma conversa com a IA sobre o repositório atual",
+	Long: `Abre uma sessão interativa com a IA sobre o repositório atual, permitindo perguntas de acompanhamento.
+Comandos disponíveis durante a conversa:
+  /file <caminho>   Anexa o conteúdo de um arquivo à próxima pergunta
+  /diff             Anexa as alterações em stage
+  /issue <ID>       Anexa uma tarefa do Jira (ex: ABC-123)
+  /branch           Anexa a branch atual, os commits recentes e os arquivos alterados
+  /help             Mostra os comandos disponíveis
+  /exit             Encerra a conversa e a salva em ~/.gojira/chats`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		session := functions.NewChatSession()
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+
+		output.Progress(i18n.T("chat.welcome"))
+		for {
+			fmt.Fprint(os.Stderr, "> ")
+			if !scanner.Scan() {
+				fmt.Fprintln(os.Stderr)
+				break
+			}
+
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			if line == "/exit" || line == "/quit" {
+				break
+			}
+
+			if strings.HasPrefix(line, "/") {
+				if err := runChatCommand(session, line); err != nil {
+					output.Progress(i18n.T("chat.command_error", err))
+				}
+				continue
+			}
+
+			completion, err := session.Ask(line)
+			if err != nil {
+				output.Progress(i18n.T("chat.answer_error", err))
+				continue
+			}
+			fmt.Println(completion.Text)
+			fmt.Println()
+		}
+
+		result := newResult(cmd, nil)
+		result.Data = map[string]int{"messages": len(session.Request.Messages)}
+
+		// Salva a conversa ao sair
+		if len(session.Request.Messages) > 0 && !dryrun.Skip(i18n.T("dryrun.write_file", functions.GetChatDir())) {
+			path, err := session.Save()
+			if err != nil {
+				return err
+			}
+			output.Progress(i18n.T("chat.saved", path))
+			result.Files = []string{path}
+		}
+
+		return emitResult(result)
+	},
+}
+
+// runChatCommand executa um comando de barra da conversa, anexando o contexto pedido
+func runChatCommand(session *functions.ChatSession, line string) error {
+	name, argument, _ := strings.Cut(line, " ")
+	argument = strings.TrimSpace(argument)
+
+	var err error
+	switch name {
+	case "/file":
+		if argument == "" {
+			return errors.New(i18n.T("chat.file_usage"))
+		}
+		err = session.AttachFile(argument)
+	case "/diff":
+		err = session.AttachDiff()
+	case "/issue":
+		if argument == "" {
+			return errors.New(i18n.T("chat.issue_usage"))
+		}
+		err = session.AttachIssue(strings.ToUpper(argument))
+	case "/branch":
+		err = session.AttachBranch()
+	case "/help":
+		output.Progress(i18n.T("chat.help"))
+		return nil
+	default:
+		return i18n.Errorf("chat.unknown_command", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	output.Progress(i18n.T("chat.attached", session.Pending()))
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(chatCmd)
+}
@@ -2,10 +2,11 @@ package cmd
 
 import (
 	"errors"
-	"fmt"
 	"github.com/spf13/cobra"
 	"gojira/functions"
 	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 )
 
 // commitCmd representa o comando para gerar mensagens de commit
@@ -18,10 +19,10 @@ var commitCmd = &cobra.Command{
 			return err
 		}
 		if !isRepo {
-			return errors.New("o diretório atual não é um repositório Git")
+			return errors.New(i18n.T("git.not_repo"))
 		}
 
-		fmt.Println("Repositório Git detectado. Preparando diffs...")
+		output.Progress(i18n.T("commit.repo_detected"))
 
 		branch, err := git.GetBranchName()
 		if err != nil {
@@ -38,8 +39,9 @@ var commitCmd = &cobra.Command{
 			if err != nil {
 				return err
 			}
-			fmt.Println("\nMensagem de commit sugerida:")
-			fmt.Println(commitMessage)
+			output.Print(i18n.T("commit.suggested"))
+			output.Print(commitMessage.Text)
+			return emitResult(newResult(cmd, commitMessage))
 		}
 		return nil
 	},
@@ -47,4 +49,4 @@ var commitCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(commitCmd)
-}
\ No newline at end of file
+}
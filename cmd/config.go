@@ -4,7 +4,15 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"gojira/services/ai"
+	"gojira/services/codehost"
+	"gojira/services/usage"
 	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/redact"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -15,6 +23,24 @@ var (
 	jiraUrl      string
 	jiraToken    string
 	jiraProject  string
+	language     string
+	uiLanguage   string
+	historyDays  int
+	historyMax   int
+	cacheEnabled bool
+	cacheTTL     int
+	cacheMaxSize int
+	budget       float64
+	budgetAction string
+	prices       []string
+	redaction    string
+	piiPatterns  []string
+	maxTokens    int
+	githubToken  string
+	githubAPIURL string
+	codeHost     string
+	codeHostURL  string
+	codeHostKey  string
 )
 
 // configCmd representa o comando para configurar o aplicativo
@@ -26,7 +52,7 @@ var configCmd = &cobra.Command{
 		// Carrega a configuração atual
 		config, err := commons.LoadConfig()
 		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
+			return i18n.Errorf("config.load_error", err)
 		}
 
 		// Atualiza a configuração com os valores das flags
@@ -38,6 +64,13 @@ var configCmd = &cobra.Command{
 			config.AIModel = modelName
 		}
 
+		if cmd.Flags().Changed("max-tokens") {
+			if maxTokens < 0 {
+				return i18n.Errorf("config.invalid_max_tokens", maxTokens)
+			}
+			config.MaxTokens = maxTokens
+		}
+
 		if jiraUrl != "" {
 			config.JiraURL = jiraUrl
 		}
@@ -50,13 +83,121 @@ var configCmd = &cobra.Command{
 			config.DefaultJira = jiraProject
 		}
 
+		if githubToken != "" {
+			config.GitHubToken = githubToken
+		}
+
+		if githubAPIURL != "" {
+			config.GitHubAPIURL = strings.TrimRight(githubAPIURL, "/")
+		}
+
+		if cmd.Flags().Changed("code-host") {
+			codeHost = strings.ToLower(codeHost)
+			if codeHost != "" && !codehost.IsKind(codeHost) {
+				return i18n.Errorf("config.invalid_code_host", codeHost)
+			}
+			config.CodeHost = codeHost
+		}
+
+		if codeHostURL != "" {
+			config.CodeHostURL = strings.TrimRight(codeHostURL, "/")
+		}
+
+		if codeHostKey != "" {
+			config.CodeHostToken = codeHostKey
+		}
+
+		if language != "" {
+			normalized, ok := i18n.Normalize(language)
+			if !ok {
+				return i18n.Errorf("config.invalid_language", language)
+			}
+			config.Language = normalized
+		}
+
+		if uiLanguage != "" {
+			normalized, ok := i18n.Normalize(uiLanguage)
+			if !ok {
+				return i18n.Errorf("config.invalid_language", uiLanguage)
+			}
+			config.UILanguage = normalized
+		}
+
+		if cmd.Flags().Changed("history-days") {
+			config.HistoryRetentionDays = historyDays
+		}
+
+		if cmd.Flags().Changed("history-max") {
+			config.HistoryMaxEntries = historyMax
+		}
+
+		if cmd.Flags().Changed("cache") {
+			config.CacheDisabled = !cacheEnabled
+		}
+
+		if cmd.Flags().Changed("cache-ttl") {
+			config.CacheTTLHours = cacheTTL
+		}
+
+		if cmd.Flags().Changed("cache-max-size") {
+			config.CacheMaxSizeMB = cacheMaxSize
+		}
+
+		if cmd.Flags().Changed("budget") {
+			config.MonthlyBudgetUSD = budget
+		}
+
+		if budgetAction != "" {
+			if budgetAction != usage.BudgetWarn && budgetAction != usage.BudgetBlock {
+				return i18n.Errorf("config.invalid_budget_action", budgetAction)
+			}
+			config.BudgetAction = budgetAction
+		}
+
+		for _, value := range prices {
+			model, price, err := parsePrice(value)
+			if err != nil {
+				return err
+			}
+			if config.Prices == nil {
+				config.Prices = make(map[string]commons.ModelPrice)
+			}
+			config.Prices[model] = price
+		}
+
+		if redaction != "" {
+			if redaction != redact.ModeMask && redaction != redact.ModeStrict && redaction != redact.ModeOff {
+				return i18n.Errorf("config.invalid_redaction", redaction)
+			}
+			config.RedactionMode = redaction
+		}
+
+		for _, value := range piiPatterns {
+			name, pattern, found := strings.Cut(value, "=")
+			if !found || name == "" || pattern == "" {
+				return i18n.Errorf("config.invalid_pii_pattern", value)
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return i18n.Errorf("redact.invalid_pattern", name, err)
+			}
+			if config.PIIPatterns == nil {
+				config.PIIPatterns = make(map[string]string)
+			}
+			config.PIIPatterns[name] = pattern
+		}
+
+		result := newResult(cmd, nil)
+
 		// Salva a configuração
-		if err := commons.SaveConfig(config); err != nil {
-			return fmt.Errorf("erro ao salvar configuração: %w", err)
+		if !dryrun.Skip(i18n.T("dryrun.write_file", commons.GetConfigFilePath())) {
+			if err := commons.SaveConfig(config); err != nil {
+				return i18n.Errorf("config.save_error", err)
+			}
+			output.Progress(i18n.T("config.updated"))
+			result.Files = []string{commons.GetConfigFilePath()}
 		}
 
-		fmt.Println("Configuração atualizada com sucesso!")
-		return nil
+		return emitResult(result)
 	},
 }
 
@@ -68,43 +209,145 @@ var configShowCmd = &cobra.Command{
 		// Carrega a configuração atual
 		config, err := commons.LoadConfig()
 		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
+			return i18n.Errorf("config.load_error", err)
 		}
 
-		fmt.Println("Configuração atual:")
-		fmt.Printf("- Provedor de IA: %s\n", config.AIProvider)
-		
+		// No modo JSON, emite a configuração sem expor o token do Jira
+		if output.IsJSON() {
+			_, model := ai.GetConfiguredProvider(config)
+			result := newResult(cmd, nil)
+			result.Data = map[string]interface{}{
+				"ai_provider":    config.AIProvider,
+				"ai_model":       model,
+				"max_tokens":     maxTokensOf(config),
+				"jira_url":       config.JiraURL,
+				"default_jira":   config.DefaultJira,
+				"jira_token_set": config.JiraToken != "",
+				"github": map[string]interface{}{
+					"api_url":   githubAPIURLOf(config),
+					"token_set": config.GitHubToken != "",
+				},
+				"code_host": map[string]interface{}{
+					"kind":      config.CodeHost,
+					"url":       config.CodeHostURL,
+					"token_set": config.CodeHostToken != "",
+				},
+				"language":    config.Language,
+				"ui_language": config.GetUILanguage(),
+				"history": map[string]int{
+					"retention_days": config.HistoryRetentionDays,
+					"max_entries":    config.HistoryMaxEntries,
+				},
+				"cache": map[string]interface{}{
+					"enabled":     !config.CacheDisabled,
+					"ttl_hours":   config.CacheTTLHours,
+					"max_size_mb": config.CacheMaxSizeMB,
+				},
+				"budget": map[string]interface{}{
+					"monthly_usd": config.MonthlyBudgetUSD,
+					"action":      budgetActionOf(config),
+				},
+				"prices": config.Prices,
+				"redaction": map[string]interface{}{
+					"mode":         redactionModeOf(config),
+					"pii_patterns": config.PIIPatterns,
+				},
+			}
+			return emitResult(result)
+		}
+
+		fmt.Println(i18n.T("config.current"))
+		fmt.Println(i18n.T("config.provider", config.AIProvider))
+
 		// Se o provedor existir, mostra o modelo atual e os disponíveis
 		if provider, exists := ai.GetProvider(config.AIProvider); exists {
 			if config.AIModel == "" {
-				fmt.Printf("- Modelo de IA: %s (padrão)\n", provider.GetDefaultModel())
+				fmt.Println(i18n.T("config.model_default", provider.GetDefaultModel()))
 			} else {
-				fmt.Printf("- Modelo de IA: %s\n", config.AIModel)
+				fmt.Println(i18n.T("config.model", config.AIModel))
 			}
-			
-			fmt.Println("- Modelos disponíveis:")
+
+			fmt.Println(i18n.T("config.models_available"))
 			for _, model := range provider.GetAvailableModels() {
 				fmt.Printf("  * %s\n", model)
 			}
 		}
-		
+		fmt.Println(i18n.T("config.max_tokens", maxTokensOf(config)))
+
 		// Mostra configuração do Jira
 		if config.JiraURL != "" {
-			fmt.Printf("- URL do Jira: %s\n", config.JiraURL)
+			fmt.Println(i18n.T("config.jira_url", config.JiraURL))
 		} else {
-			fmt.Println("- URL do Jira: Não configurado")
+			fmt.Println(i18n.T("config.jira_url_unset"))
 		}
-		
+
 		if config.DefaultJira != "" {
-			fmt.Printf("- Projeto Jira padrão: %s\n", config.DefaultJira)
+			fmt.Println(i18n.T("config.jira_project", config.DefaultJira))
 		} else {
-			fmt.Println("- Projeto Jira padrão: Não configurado")
+			fmt.Println(i18n.T("config.jira_project_unset"))
 		}
-		
+
 		if config.JiraToken != "" {
-			fmt.Println("- Token do Jira: Configurado")
+			fmt.Println(i18n.T("config.jira_token_set"))
+		} else {
+			fmt.Println(i18n.T("config.jira_token_unset"))
+		}
+
+		// Mostra a configuração da API do GitHub
+		fmt.Println(i18n.T("config.github_api_url", githubAPIURLOf(config)))
+		if config.GitHubToken != "" {
+			fmt.Println(i18n.T("config.github_token_set"))
+		} else {
+			fmt.Println(i18n.T("config.github_token_unset"))
+		}
+
+		// Mostra a plataforma dos PRs
+		if config.CodeHost != "" {
+			fmt.Println(i18n.T("config.code_host", config.CodeHost))
+		} else {
+			fmt.Println(i18n.T("config.code_host", i18n.T("config.code_host_detect")))
+		}
+		if config.CodeHostURL != "" {
+			fmt.Println(i18n.T("config.code_host_url", config.CodeHostURL))
+		}
+		if config.CodeHostToken != "" {
+			fmt.Println(i18n.T("config.code_host_token_set"))
+		} else {
+			fmt.Println(i18n.T("config.code_host_token_unset"))
+		}
+
+		// Mostra a configuração de idioma
+		if config.Language != "" {
+			fmt.Println(i18n.T("config.language", config.Language))
+		} else {
+			fmt.Println(i18n.T("config.language_unset"))
+		}
+		fmt.Println(i18n.T("config.ui_language", config.GetUILanguage()))
+
+		// Mostra a retenção do histórico
+		fmt.Println(i18n.T("config.history_retention", config.HistoryRetentionDays, config.HistoryMaxEntries))
+
+		// Mostra a configuração do cache
+		if config.CacheDisabled {
+			fmt.Println(i18n.T("config.cache_disabled"))
+		} else {
+			fmt.Println(i18n.T("config.cache", config.CacheTTLHours, config.CacheMaxSizeMB))
+		}
+
+		// Mostra o orçamento mensal e os preços personalizados
+		if config.MonthlyBudgetUSD > 0 {
+			fmt.Println(i18n.T("config.budget", config.MonthlyBudgetUSD, budgetActionOf(config)))
 		} else {
-			fmt.Println("- Token do Jira: Não configurado")
+			fmt.Println(i18n.T("config.budget_unset"))
+		}
+		for model, price := range config.Prices {
+			fmt.Println(i18n.T("config.price", model, price.Input, price.Output))
+		}
+
+		// Mostra a redação de segredos e dados pessoais
+		fmt.Println(i18n.T("config.redaction", redactionModeOf(config)))
+		for name, pattern := range config.PIIPatterns {
+			fmt.Println(i18n.T("config.pii_pattern", name, pattern))
 		}
 
 		return nil
@@ -115,34 +358,120 @@ var configShowCmd = &cobra.Command{
 var configProvidersCmd = &cobra.Command{
 	Use:   "providers",
 	Short: "Lista os provedores de IA disponíveis",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Provedores de IA disponíveis:")
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if output.IsJSON() {
+			providers := make(map[string]interface{})
+			for name := range ai.ProviderFactory {
+				provider, _ := ai.GetProvider(name)
+				providers[name] = map[string]interface{}{
+					"name":          provider.GetName(),
+					"default_model": provider.GetDefaultModel(),
+					"models":        provider.GetAvailableModels(),
+				}
+			}
+			result := newResult(cmd, nil)
+			result.Data = providers
+			return emitResult(result)
+		}
+
+		fmt.Println(i18n.T("config.providers_available"))
 		for name := range ai.ProviderFactory {
 			provider, _ := ai.GetProvider(name)
 			fmt.Printf("- %s: %s\n", name, provider.GetName())
-			fmt.Println("  Modelos disponíveis:")
+			fmt.Println(i18n.T("config.provider_models"))
 			for _, model := range provider.GetAvailableModels() {
 				if model == provider.GetDefaultModel() {
-					fmt.Printf("  * %s (padrão)\n", model)
+					fmt.Println(i18n.T("config.provider_model_default", model))
 				} else {
 					fmt.Printf("  * %s\n", model)
 				}
 			}
 		}
+		return nil
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(configCmd)
-	
+
 	// Adiciona os subcomandos
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configProvidersCmd)
-	
+
 	// Adiciona as flags
 	configCmd.Flags().StringVarP(&providerName, "provider", "p", "", "Nome do provedor de IA (openai, anthropic)")
 	configCmd.Flags().StringVarP(&modelName, "model", "m", "", "Nome do modelo de IA")
+	configCmd.Flags().IntVar(&maxTokens, "max-tokens", 0, "Limite de tokens das respostas da IA (0 para o padrão do provedor)")
 	configCmd.Flags().StringVarP(&jiraUrl, "jira-url", "j", "", "URL da instância do Jira")
 	configCmd.Flags().StringVarP(&jiraToken, "jira-token", "t", "", "Token de autenticação do Jira")
 	configCmd.Flags().StringVarP(&jiraProject, "jira-project", "r", "", "ID do projeto Jira padrão")
-}
\ No newline at end of file
+	configCmd.Flags().StringVar(&githubToken, "github-token", "", "Token da API do GitHub para criar PRs sem o gh")
+	configCmd.Flags().StringVar(&githubAPIURL, "github-api-url", "", "URL da API do GitHub (ex: https://github.empresa.com/api/v3 no GitHub Enterprise)")
+	configCmd.Flags().StringVar(&codeHost, "code-host", "", "Plataforma dos PRs (github, gitlab, gitea, bitbucket); vazio para detectar pelo remoto origin")
+	configCmd.Flags().StringVar(&codeHostURL, "code-host-url", "", "URL da instância self-hosted do GitLab, Gitea ou Bitbucket Server")
+	configCmd.Flags().StringVar(&codeHostKey, "code-host-token", "", "Token da API do GitLab, Gitea ou Bitbucket Server")
+	configCmd.Flags().StringVarP(&language, "language", "l", "", "Idioma do conteúdo gerado (pt, en)")
+	configCmd.Flags().StringVar(&uiLanguage, "ui-language", "", "Idioma das mensagens da CLI (pt, en)")
+	configCmd.Flags().IntVar(&historyDays, "history-days", 0, "Dias que as gerações ficam no histórico (0 para sempre)")
+	configCmd.Flags().IntVar(&historyMax, "history-max", 0, "Número máximo de gerações no histórico (0 para ilimitado)")
+	configCmd.Flags().BoolVar(&cacheEnabled, "cache", true, "Ativa ou desativa o cache de respostas (--cache=false para desativar)")
+	configCmd.Flags().IntVar(&cacheTTL, "cache-ttl", 0, "Tempo de vida das respostas em cache, em horas (0 para o padrão de 24h)")
+	configCmd.Flags().IntVar(&cacheMaxSize, "cache-max-size", 0, "Tamanho máximo do cache em MB (0 para o padrão de 50MB)")
+	configCmd.Flags().Float64Var(&budget, "budget", 0, "Orçamento mensal em dólares (0 para sem limite)")
+	configCmd.Flags().StringVar(&budgetAction, "budget-action", "", "Ação ao exceder o orçamento (warn, block)")
+	configCmd.Flags().StringVar(&redaction, "redaction", "", "Tratamento de segredos e dados pessoais nos prompts (mask, strict, off)")
+	configCmd.Flags().StringArrayVar(&piiPatterns, "pii-pattern", nil, "Padrão adicional de dados pessoais a mascarar (ex: matricula=MAT-[0-9]{6})")
+	configCmd.Flags().StringArrayVar(&prices, "price", nil, "Preço de um modelo em dólares por milhão de tokens (ex: gpt-4o=2.5,10)")
+}
+
+// budgetActionOf retorna a ação configurada para quando o orçamento é excedido
+func budgetActionOf(config *commons.Config) string {
+	if config.BudgetAction == "" {
+		return usage.BudgetWarn
+	}
+	return config.BudgetAction
+}
+
+// githubAPIURLOf retorna a URL da API do GitHub em uso
+func githubAPIURLOf(config *commons.Config) string {
+	if config.GitHubAPIURL == "" {
+		return codehost.DefaultGitHubAPIURL
+	}
+	return config.GitHubAPIURL
+}
+
+// maxTokensOf retorna o limite de tokens das respostas em vigor: o configurado ou o padrão do provedor
+func maxTokensOf(config *commons.Config) int {
+	if config.MaxTokens == 0 {
+		return ai.DefaultMaxTokens(config.AIProvider)
+	}
+	return config.MaxTokens
+}
+
+// redactionModeOf retorna o modo de redação configurado
+func redactionModeOf(config *commons.Config) string {
+	if config.RedactionMode == "" {
+		return redact.ModeMask
+	}
+	return config.RedactionMode
+}
+
+// parsePrice interpreta um preço no formato modelo=entrada,saída
+func parsePrice(value string) (string, commons.ModelPrice, error) {
+	model, values, found := strings.Cut(value, "=")
+	inputValue, outputValue, hasOutput := strings.Cut(values, ",")
+	if !found || model == "" || !hasOutput {
+		return "", commons.ModelPrice{}, i18n.Errorf("config.invalid_price", value)
+	}
+
+	inputPrice, err := strconv.ParseFloat(strings.TrimSpace(inputValue), 64)
+	if err != nil {
+		return "", commons.ModelPrice{}, i18n.Errorf("config.invalid_price", value)
+	}
+	outputPrice, err := strconv.ParseFloat(strings.TrimSpace(outputValue), 64)
+	if err != nil {
+		return "", commons.ModelPrice{}, i18n.Errorf("config.invalid_price", value)
+	}
+
+	return strings.TrimSpace(model), commons.ModelPrice{Input: inputPrice, Output: outputPrice}, nil
+}
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"gojira/services"
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -32,10 +36,10 @@ var branchCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Se não foi fornecido um nome de branch, pergunta para o usuário
 		if branchName == "" {
-			fmt.Println("Digite o nome da branch (sem o prefixo):")
+			output.Progress(i18n.T("input.branch_name"))
 			_, err := fmt.Scanln(&branchName)
 			if err != nil {
-				return fmt.Errorf("erro ao ler o nome da branch: %w", err)
+				return i18n.Errorf("input.branch_name_error", err)
 			}
 		}
 
@@ -44,13 +48,13 @@ var branchCmd = &cobra.Command{
 			branchPrefix = "feature"
 		}
 
-		// Se a issue não foi fornecida e o nome não contém uma issue, 
+		// Se a issue não foi fornecida e o nome não contém uma issue,
 		// solicita a issue do usuário
 		if issueKey == "" && !strings.Contains(branchName, "-") {
-			fmt.Println("Digite a chave da issue (ex: ABC-123):")
+			output.Progress(i18n.T("input.issue_key"))
 			_, err := fmt.Scanln(&issueKey)
 			if err != nil {
-				return fmt.Errorf("erro ao ler a chave da issue: %w", err)
+				return i18n.Errorf("input.issue_key_error", err)
 			}
 		}
 
@@ -66,15 +70,21 @@ var branchCmd = &cobra.Command{
 		}
 
 		// Cria a branch
-		cmd := exec.Command("git", "checkout", "-b", formattedName)
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("erro ao criar a branch: %w", err)
+		if !dryrun.Skip(i18n.T("dryrun.create_branch", formattedName)) {
+			gitCmd := exec.Command("git", "checkout", "-b", formattedName)
+			gitCmd.Stdout = output.ProgressWriter()
+			gitCmd.Stderr = os.Stderr
+			if err := gitCmd.Run(); err != nil {
+				return i18n.Errorf("dev.branch_create_error", err)
+			}
+
+			output.Progress(i18n.T("dev.branch_created", formattedName))
 		}
 
-		fmt.Printf("Branch %s criada com sucesso!\n", formattedName)
-		return nil
+		result := newResult(cmd, nil)
+		result.IssueKey = strings.ToUpper(issueKey)
+		result.Data = map[string]string{"branch": formattedName}
+		return emitResult(result)
 	},
 }
 
@@ -85,26 +95,26 @@ var startCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Verifica se uma issue foi fornecida
 		if issueKey == "" {
-			fmt.Println("Digite a chave da issue (ex: ABC-123):")
+			output.Progress(i18n.T("input.issue_key"))
 			_, err := fmt.Scanln(&issueKey)
 			if err != nil {
-				return fmt.Errorf("erro ao ler a chave da issue: %w", err)
+				return i18n.Errorf("input.issue_key_error", err)
 			}
 		}
 
 		// Busca os detalhes da issue no Jira
 		issue, err := services.GetJiraIssue(issueKey)
 		if err != nil {
-			fmt.Printf("Não foi possível obter detalhes da issue %s: %v\n", issueKey, err)
-			fmt.Println("Deseja continuar mesmo assim? (s/n)")
+			output.Progress(i18n.T("dev.issue_fetch_warning", issueKey, err))
+			output.Progress(i18n.T("dev.continue_anyway"))
 			var response string
 			_, err := fmt.Scanln(&response)
-			if err != nil || strings.ToLower(response) != "s" {
-				return fmt.Errorf("operação cancelada pelo usuário")
+			if err != nil || strings.ToLower(response) != i18n.T("input.yes") {
+				return errors.New(i18n.T("dev.cancelled"))
 			}
 		} else {
-			fmt.Printf("Tarefa: %s - %s\n", issue.Key, issue.Summary)
-			
+			output.Progress(i18n.T("dev.task", issue.Key, issue.Summary))
+
 			// Cria um nome de branch a partir do título da tarefa
 			suggestedBranchName := strings.ToLower(issue.Summary)
 			suggestedBranchName = strings.ReplaceAll(suggestedBranchName, " ", "-")
@@ -115,12 +125,12 @@ var startCmd = &cobra.Command{
 				}
 				return -1
 			}, suggestedBranchName)
-			
+
 			// Limita o tamanho do nome da branch
 			if len(suggestedBranchName) > 50 {
 				suggestedBranchName = suggestedBranchName[:50]
 			}
-			
+
 			// Define o tipo de branch com base no tipo de issue
 			switch issue.Type {
 			case services.JiraEpic:
@@ -130,20 +140,20 @@ var startCmd = &cobra.Command{
 			default:
 				branchPrefix = "feature"
 			}
-			
+
 			// Confirma o nome da branch
-			fmt.Printf("Nome sugerido para a branch: %s/%s-%s\n", branchPrefix, issue.Key, suggestedBranchName)
-			fmt.Println("Deseja usar este nome? (s/n)")
+			output.Progress(i18n.T("dev.suggested_branch", branchPrefix, issue.Key, suggestedBranchName))
+			output.Progress(i18n.T("dev.use_name"))
 			var response string
 			_, err := fmt.Scanln(&response)
-			if err == nil && strings.ToLower(response) == "s" {
+			if err == nil && strings.ToLower(response) == i18n.T("input.yes") {
 				branchName = suggestedBranchName
 				issueKey = issue.Key
 			} else {
-				fmt.Println("Digite o nome desejado para a branch (sem o prefixo e sem a issue):")
+				output.Progress(i18n.T("dev.branch_name_custom"))
 				_, err := fmt.Scanln(&branchName)
 				if err != nil {
-					return fmt.Errorf("erro ao ler o nome da branch: %w", err)
+					return i18n.Errorf("input.branch_name_error", err)
 				}
 			}
 		}
@@ -160,13 +170,13 @@ var checklistCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Obtém a branch atual
 		gitCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-		output, err := gitCmd.Output()
+		branchOutput, err := gitCmd.Output()
 		if err != nil {
-			return fmt.Errorf("erro ao obter o nome da branch atual: %w", err)
+			return i18n.Errorf("dev.current_branch_error", err)
 		}
-		
-		branch := strings.TrimSpace(string(output))
-		
+
+		branch := strings.TrimSpace(string(branchOutput))
+
 		// Extrai a issue da branch
 		issuePattern := "[A-Z]+-[0-9]+"
 		matches, err := filepath.Match(issuePattern, branch)
@@ -180,22 +190,22 @@ var checklistCmd = &cobra.Command{
 				}
 			}
 		}
-		
+
 		// Se ainda não temos a issue, pergunta ao usuário
 		if issueKey == "" {
-			fmt.Println("Digite a chave da issue (ex: ABC-123):")
+			output.Progress(i18n.T("input.issue_key"))
 			_, err := fmt.Scanln(&issueKey)
 			if err != nil {
-				return fmt.Errorf("erro ao ler a chave da issue: %w", err)
+				return i18n.Errorf("input.issue_key_error", err)
 			}
 		}
-		
+
 		// Busca os detalhes da issue no Jira
 		issue, err := services.GetJiraIssue(issueKey)
 		if err != nil {
-			return fmt.Errorf("não foi possível obter detalhes da issue %s: %w", issueKey, err)
+			return i18n.Errorf("dev.issue_fetch_error", issueKey, err)
 		}
-		
+
 		// Constrói o prompt para gerar o checklist
 		prompt := fmt.Sprintf(
 			"Crie um checklist detalhado para a issue '%s' com título '%s'.\n\n"+
@@ -211,57 +221,51 @@ var checklistCmd = &cobra.Command{
 				"- [ ] Tarefa 2\n   - [ ] Subtarefa 2.1\n",
 			issue.Key, issue.Summary, issue.Description,
 		)
-		
-		// Carrega configuração
-		config, err := commons.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
-		}
-		
-		// Obtém o provedor de IA configurado
-		provider, exists := ai.GetProvider(config.AIProvider)
-		if !exists {
-			provider = ai.GetDefaultProvider()
-		}
-		
+		prompt += i18n.PromptInstruction(i18n.Portuguese)
+
 		// Gera o checklist
-		checklist, err := provider.GetCompletions(prompt, config.AIModel)
+		checklist, err := ai.Complete(prompt)
 		if err != nil {
-			return fmt.Errorf("erro ao gerar checklist: %w", err)
+			return i18n.Errorf("dev.checklist_error", err)
 		}
-		
+
 		// Salva o checklist em um arquivo
+		result := newResult(cmd, checklist)
+		result.IssueKey = issueKey
+
 		filename := fmt.Sprintf("checklist-%s.md", issueKey)
-		err = os.WriteFile(filename, []byte(checklist), 0644)
-		if err != nil {
-			return fmt.Errorf("erro ao salvar checklist: %w", err)
+		if !dryrun.Skip(i18n.T("dryrun.write_file", filename)) {
+			err = os.WriteFile(filename, []byte(checklist.Text), 0644)
+			if err != nil {
+				return i18n.Errorf("dev.checklist_save_error", err)
+			}
+			output.Progress(i18n.T("dev.checklist_saved", filename))
+			result.Files = []string{filename}
 		}
-		
-		fmt.Printf("Checklist gerado e salvo em %s\n", filename)
-		fmt.Println("\nChecklist:")
-		fmt.Println(checklist)
-		
-		return nil
+
+		output.Print(i18n.T("dev.checklist"))
+		output.Print(checklist.Text)
+		return emitResult(result)
 	},
 }
 
 func init() {
 	RootCmd.AddCommand(devCmd)
-	
+
 	// Adiciona os subcomandos
 	devCmd.AddCommand(branchCmd)
 	devCmd.AddCommand(startCmd)
 	devCmd.AddCommand(checklistCmd)
-	
+
 	// Flags para o comando branch
 	branchCmd.Flags().StringVarP(&branchName, "name", "n", "", "Nome da branch")
 	branchCmd.Flags().StringVarP(&branchPrefix, "prefix", "p", "", "Prefixo da branch (feature, fix, chore)")
 	branchCmd.Flags().StringVarP(&issueKey, "issue", "i", "", "Chave da issue (ex: ABC-123)")
-	
+
 	// Flags para o comando start
 	startCmd.Flags().StringVarP(&issueKey, "issue", "i", "", "Chave da issue (ex: ABC-123)")
 	startCmd.Flags().BoolVarP(&skipChecklist, "no-checklist", "c", false, "Não gerar checklist")
-	
+
 	// Flags para o comando checklist
 	checklistCmd.Flags().StringVarP(&issueKey, "issue", "i", "", "Chave da issue (ex: ABC-123)")
-}
\ No newline at end of file
+}
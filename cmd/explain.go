@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
+	"gojira/utils/source"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,11 +17,14 @@ import (
 
 var (
 	// Flags para o comando explain
-	filePath   string
-	lineStart  int
-	lineEnd    int
-	outputFile string
-	langLevel  string
+	filePath      string
+	lineStart     int
+	lineEnd       int
+	outputFile    string
+	langLevel     string
+	symbolName    string
+	withDeps      bool
+	contextTokens int
 )
 
 // explainCmd representa o comando para explicar código
@@ -26,27 +34,51 @@ var explainCmd = &cobra.Command{
 	Long:  `Analisa e explica o funcionamento de trechos de código, classes, funções ou arquivos inteiros, tornando mais fácil entender código complexo ou legado.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if filePath == "" {
-			return fmt.Errorf("é necessário fornecer o caminho para um arquivo")
+			return errors.New(i18n.T("explain.no_file"))
 		}
 
 		// Verifica se o arquivo existe
 		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			return fmt.Errorf("o arquivo %s não existe", filePath)
+			return i18n.Errorf("explain.file_missing", filePath)
+		}
+
+		// Verifica se a política permite enviar o arquivo
+		if !policy.Allowed(filePath) {
+			return i18n.Errorf("policy.file_denied", filePath)
 		}
 
 		// Lê o conteúdo do arquivo
 		content, err := os.ReadFile(filePath)
 		if err != nil {
-			return fmt.Errorf("erro ao ler o arquivo: %w", err)
+			return i18n.Errorf("explain.read_error", err)
+		}
+
+		if symbolName != "" && (lineStart != 0 || lineEnd != 0) {
+			return errors.New(i18n.T("explain.symbol_and_lines"))
 		}
 
 		// Se não foram especificadas linhas, processa o arquivo inteiro
 		var codeToExplain string
-		if lineStart == 0 && lineEnd == 0 {
+		var references []*source.Symbol
+		if symbolName != "" {
+			symbol, err := source.FindSymbol(filePath, symbolName)
+			if err != nil {
+				return err
+			}
+			output.Progress(i18n.T("explain.symbol_found", symbol.Name, symbol.File, symbol.StartLine, symbol.EndLine))
+			codeToExplain = symbol.Code
+
+			if withDeps {
+				references, err = symbolReferences(symbol)
+				if err != nil {
+					return err
+				}
+			}
+		} else if lineStart == 0 && lineEnd == 0 {
 			codeToExplain = string(content)
 		} else {
 			lines := strings.Split(string(content), "\n")
-			
+
 			// Validação das linhas
 			if lineStart < 1 {
 				lineStart = 1
@@ -59,7 +91,7 @@ var explainCmd = &cobra.Command{
 			}
 
 			// Extrai o código das linhas especificadas
-			selectedLines := lines[lineStart-1:lineEnd]
+			selectedLines := lines[lineStart-1 : lineEnd]
 			codeToExplain = strings.Join(selectedLines, "\n")
 		}
 
@@ -68,36 +100,29 @@ var explainCmd = &cobra.Command{
 
 		// Constrói o prompt para a IA
 		prompt := buildExplanationPrompt(codeToExplain, language)
-
-		// Carrega configuração
-		config, err := commons.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
-		}
-
-		// Obtém o provedor de IA configurado
-		provider, exists := ai.GetProvider(config.AIProvider)
-		if !exists {
-			provider = ai.GetDefaultProvider()
-		}
+		prompt += buildReferencesPrompt(references, language)
+		prompt += buildPackageContextPrompt(filePath, contextTokens)
+		prompt += i18n.PromptInstruction(i18n.Portuguese)
 
 		// Gera a explicação
-		explanation, err := provider.GetCompletions(prompt, config.AIModel)
+		explanation, err := ai.Complete(prompt)
 		if err != nil {
-			return fmt.Errorf("erro ao gerar explicação: %w", err)
+			return i18n.Errorf("explain.error", err)
 		}
+		result := newResult(cmd, explanation)
 
 		// Salva a explicação em um arquivo se solicitado
-		if outputFile != "" {
-			if err := os.WriteFile(outputFile, []byte(explanation), 0644); err != nil {
-				return fmt.Errorf("erro ao salvar a explicação: %w", err)
+		if outputFile != "" && !dryrun.Skip(i18n.T("dryrun.write_file", outputFile)) {
+			if err := os.WriteFile(outputFile, []byte(explanation.Text), 0644); err != nil {
+				return i18n.Errorf("explain.save_error", err)
 			}
-			fmt.Printf("Explicação salva em %s\n", outputFile)
+			output.Progress(i18n.T("explain.saved", outputFile))
+			result.Files = []string{outputFile}
 		}
 
 		// Exibe a explicação
-		fmt.Println(explanation)
-		return nil
+		output.Print(explanation.Text)
+		return emitResult(result)
 	},
 }
 
@@ -126,44 +151,93 @@ func buildExplanationPrompt(code, language string) string {
 	)
 }
 
+// symbolReferences retorna as definições usadas pelo símbolo que a política permite enviar
+func symbolReferences(symbol *source.Symbol) ([]*source.Symbol, error) {
+	references, err := source.References(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := []*source.Symbol{}
+	for _, reference := range references {
+		if policy.Allowed(reference.File) {
+			allowed = append(allowed, reference)
+		}
+	}
+	if len(allowed) > 0 {
+		output.Progress(i18n.T("explain.references_found", len(allowed)))
+	}
+	return allowed, nil
+}
+
+// buildReferencesPrompt acrescenta ao prompt as definições referenciadas pelo código, como contexto
+func buildReferencesPrompt(references []*source.Symbol, language string) string {
+	if len(references) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("\n\nPara contexto, estas são as definições de tipos e funções usados pelo código. " +
+		"Não as explique em detalhe, use-as apenas para entender o código acima:\n")
+	for _, reference := range references {
+		builder.WriteString(fmt.Sprintf("\n%s (%s:%d):\n```%s\n%s\n```\n",
+			reference.Name, reference.File, reference.StartLine, language, reference.Code))
+	}
+	return builder.String()
+}
+
+// buildPackageContextPrompt acrescenta ao prompt os tipos e as assinaturas do pacote e dos pacotes
+// importados do mesmo módulo, para que a IA não precise adivinhá-los. Só se aplica a arquivos Go.
+func buildPackageContextPrompt(file string, maxTokens int) string {
+	packageContext, err := source.GoPackageContext(file, maxTokens)
+	if err != nil || packageContext == "" {
+		return ""
+	}
+
+	output.Progress(i18n.T("source.context_added", dryrun.EstimateTokens(packageContext)))
+	return "\n\nPara contexto, estes são os tipos e as assinaturas de funções do mesmo pacote e dos pacotes " +
+		"do módulo importados pelo arquivo. Use-os como referência, sem inventar outras assinaturas:\n```go\n" +
+		packageContext + "```"
+}
+
 // getLanguageFromExtension determina a linguagem de programação com base na extensão do arquivo
 func getLanguageFromExtension(ext string) string {
 	ext = strings.TrimPrefix(ext, ".")
 	languageMap := map[string]string{
-		"go":     "Go",
-		"py":     "Python",
-		"js":     "JavaScript",
-		"ts":     "TypeScript",
-		"java":   "Java",
-		"c":      "C",
-		"cpp":    "C++",
-		"cs":     "C#",
-		"php":    "PHP",
-		"rb":     "Ruby",
-		"swift":  "Swift",
-		"kt":     "Kotlin",
-		"rs":     "Rust",
-		"sh":     "Shell",
-		"sql":    "SQL",
-		"html":   "HTML",
-		"css":    "CSS",
-		"json":   "JSON",
-		"xml":    "XML",
-		"yaml":   "YAML",
-		"yml":    "YAML",
-		"md":     "Markdown",
-		"dart":   "Dart",
-		"scala":  "Scala",
-		"pl":     "Perl",
-		"clj":    "Clojure",
-		"exs":    "Elixir",
-		"ex":     "Elixir",
-		"f":      "Fortran",
-		"f90":    "Fortran",
-		"hs":     "Haskell",
-		"lua":    "Lua",
-		"m":      "Objective-C",
-		"r":      "R",
+		"go":    "Go",
+		"py":    "Python",
+		"js":    "JavaScript",
+		"ts":    "TypeScript",
+		"java":  "Java",
+		"c":     "C",
+		"cpp":   "C++",
+		"cs":    "C#",
+		"php":   "PHP",
+		"rb":    "Ruby",
+		"swift": "Swift",
+		"kt":    "Kotlin",
+		"rs":    "Rust",
+		"sh":    "Shell",
+		"sql":   "SQL",
+		"html":  "HTML",
+		"css":   "CSS",
+		"json":  "JSON",
+		"xml":   "XML",
+		"yaml":  "YAML",
+		"yml":   "YAML",
+		"md":    "Markdown",
+		"dart":  "Dart",
+		"scala": "Scala",
+		"pl":    "Perl",
+		"clj":   "Clojure",
+		"exs":   "Elixir",
+		"ex":    "Elixir",
+		"f":     "Fortran",
+		"f90":   "Fortran",
+		"hs":    "Haskell",
+		"lua":   "Lua",
+		"m":     "Objective-C",
+		"r":     "R",
 	}
 
 	if language, ok := languageMap[strings.ToLower(ext)]; ok {
@@ -179,9 +253,12 @@ func init() {
 	explainCmd.Flags().StringVarP(&filePath, "file", "f", "", "Caminho para o arquivo a ser explicado")
 	explainCmd.Flags().IntVarP(&lineStart, "start", "s", 0, "Linha inicial (opcional)")
 	explainCmd.Flags().IntVarP(&lineEnd, "end", "e", 0, "Linha final (opcional)")
-	explainCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Arquivo para salvar a explicação (opcional)")
+	explainCmd.Flags().StringVar(&symbolName, "symbol", "", "Função, tipo ou método (Tipo.Método) a ser explicado, no lugar das linhas")
+	explainCmd.Flags().BoolVar(&withDeps, "with-deps", false, "Inclui as definições dos tipos e funções usados pelo símbolo")
+	explainCmd.Flags().IntVar(&contextTokens, "context-tokens", source.DefaultContextTokens, "Orçamento de tokens para o contexto do pacote em arquivos Go (0 para desativar)")
+	explainCmd.Flags().StringVarP(&outputFile, "output-file", "o", "", "Arquivo para salvar a explicação (opcional)")
 	explainCmd.Flags().StringVarP(&langLevel, "level", "l", "intermediate", "Nível de experiência do desenvolvedor (beginner, intermediate, expert)")
 
 	// Marca o parâmetro de arquivo como obrigatório
 	_ = explainCmd.MarkFlagRequired("file")
-}
\ No newline at end of file
+}
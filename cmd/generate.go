@@ -3,6 +3,8 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	"gojira/functions"
+	"gojira/utils/dryrun"
+	"gojira/utils/output"
 )
 
 // readmeCmd representa o comando para gerar o README.md
@@ -10,7 +12,16 @@ var readmeCmd = &cobra.Command{
 	Use:   "readme",
 	Short: "Gera um README.md com base na estrutura e conteúdo do projeto",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return functions.GenerateReadme()
+		readme, err := functions.GenerateReadme()
+		if err != nil {
+			return err
+		}
+
+		result := newResult(cmd, readme)
+		if !dryrun.IsEnabled() {
+			result.Files = []string{functions.ReadmeFile}
+		}
+		return emitResult(result)
 	},
 }
 
@@ -19,7 +30,13 @@ var analysisCmd = &cobra.Command{
 	Use:   "analysis",
 	Short: "Gera uma análise de código-fonte com base nos arquivos do projeto",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return functions.GenerateAnalysis()
+		analysis, err := functions.GenerateAnalysis()
+		if err != nil {
+			return err
+		}
+
+		output.Print(analysis.Text)
+		return emitResult(newResult(cmd, analysis))
 	},
 }
 
@@ -31,8 +48,8 @@ var generateCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(generateCmd)
-	
+
 	// Adiciona os comandos filhos
 	generateCmd.AddCommand(readmeCmd)
 	generateCmd.AddCommand(analysisCmd)
-}
\ No newline at end of file
+}
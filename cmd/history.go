@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"gojira/services/ai"
+	"gojira/services/cache"
+	"gojira/services/history"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
+	"strings"
+	"time"
+)
+
+var (
+	// Flags para o comando de histórico
+	historyLimit    int
+	historyCommand  string
+	rerunProvider   string
+	rerunModel      string
+	pruneDays       int
+	pruneMaxEntries int
+)
+
+// historyCmd representa o comando para consultar o histórico de gerações
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Consulta o histórico de gerações",
+	Long:  `Consulta as gerações feitas pelo Gojira (prompt, resposta, provedor, modelo e duração), permitindo revê-las, executá-las novamente com outro provedor e remover as antigas.`,
+}
+
+// historyListCmd representa o comando para listar as gerações
+var historyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista as gerações mais recentes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := history.List()
+		if err != nil {
+			return err
+		}
+
+		// Aplica os filtros
+		filtered := []*history.Entry{}
+		for _, entry := range entries {
+			if historyCommand != "" && !strings.HasPrefix(entry.Command, historyCommand) {
+				continue
+			}
+			filtered = append(filtered, entry)
+			if historyLimit > 0 && len(filtered) >= historyLimit {
+				break
+			}
+		}
+
+		if output.IsJSON() {
+			// O prompt e a resposta completos ficam para o history show
+			items := make([]map[string]interface{}, 0, len(filtered))
+			for _, entry := range filtered {
+				items = append(items, map[string]interface{}{
+					"id":          entry.ID,
+					"command":     entry.Command,
+					"provider":    entry.Provider,
+					"model":       entry.Model,
+					"duration_ms": entry.DurationMs,
+					"created_at":  entry.CreatedAt,
+				})
+			}
+			result := newResult(cmd, nil)
+			result.Data = items
+			return emitResult(result)
+		}
+
+		if len(filtered) == 0 {
+			fmt.Println(i18n.T("history.empty"))
+			return nil
+		}
+
+		for _, entry := range filtered {
+			fmt.Printf("%s  %s  %-16s %s/%s (%s)\n",
+				entry.ID, entry.CreatedAt.Format("2006-01-02 15:04"), entry.Command,
+				entry.Provider, entry.Model, time.Duration(entry.DurationMs)*time.Millisecond)
+		}
+		return nil
+	},
+}
+
+// historyShowCmd representa o comando para mostrar uma geração
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Mostra o prompt e a resposta de uma geração",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entry, err := history.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		if output.IsJSON() {
+			result := newResult(cmd, nil)
+			result.Text = entry.Response
+			result.Provider = entry.Provider
+			result.Model = entry.Model
+			result.Data = entry
+			return emitResult(result)
+		}
+
+		fmt.Println(i18n.T("history.show_header", entry.ID, entry.Command, entry.CreatedAt.Format("2006-01-02 15:04:05")))
+		fmt.Println(i18n.T("history.show_provider", entry.Provider, entry.Model, time.Duration(entry.DurationMs)*time.Millisecond))
+		if entry.InputTokens > 0 || entry.OutputTokens > 0 {
+			fmt.Println(i18n.T("usage.summary", entry.InputTokens, entry.OutputTokens, entry.CostUSD))
+		}
+		fmt.Println(i18n.T("history.show_prompt"))
+		fmt.Println(entry.Prompt)
+		fmt.Println(i18n.T("history.show_response"))
+		fmt.Println(entry.Response)
+		return nil
+	},
+}
+
+// historyRerunCmd representa o comando para executar novamente uma geração
+var historyRerunCmd = &cobra.Command{
+	Use:   "rerun <id>",
+	Short: "Executa novamente o prompt de uma geração, opcionalmente com outro provedor",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entry, err := history.Get(args[0])
+		if err != nil {
+			return err
+		}
+
+		// Usa o provedor informado ou o configurado
+		var provider ai.Provider
+		model := rerunModel
+		if rerunProvider != "" {
+			var exists bool
+			provider, exists = ai.GetProvider(strings.ToLower(rerunProvider))
+			if !exists {
+				return i18n.Errorf("history.unknown_provider", rerunProvider)
+			}
+
+			// Os arquivos da geração original precisam ser permitidos também para o novo provedor
+			if err := policy.Use(rerunProvider); err != nil {
+				return err
+			}
+			for _, file := range entry.Files {
+				if !policy.Allowed(file) {
+					return i18n.Errorf("policy.file_denied", file)
+				}
+			}
+		} else {
+			config, err := commons.LoadConfig()
+			if err != nil {
+				return i18n.Errorf("config.load_error", err)
+			}
+			var configuredModel string
+			provider, configuredModel = ai.GetConfiguredProvider(config)
+			if model == "" {
+				model = configuredModel
+			}
+		}
+
+		// Reexecutar serve para comparar respostas, então sempre consulta o provedor
+		cache.SetEnabled(false)
+
+		// A requisição completa é repetida; as gerações gravadas antes dela existir têm apenas o prompt
+		request := ai.NewRequest(entry.Prompt)
+		if len(entry.Request) > 0 {
+			request = &ai.Request{}
+			if err := json.Unmarshal(entry.Request, request); err != nil {
+				return i18n.Errorf("history.request_error", entry.ID, err)
+			}
+		}
+
+		output.Progress(i18n.T("history.rerunning", entry.ID))
+		var completion *ai.Completion
+		if request.Schema != nil {
+			var response interface{}
+			completion, err = ai.CompleteJSONRequestWith(provider, model, request, &response)
+		} else {
+			completion, err = ai.CompleteRequestWith(provider, model, request)
+		}
+		if err != nil {
+			return err
+		}
+
+		output.Print(i18n.T("history.original_response", entry.Provider, entry.Model))
+		output.Print(entry.Response)
+		output.Print(i18n.T("history.new_response", completion.Provider, completion.Model))
+		output.Print(completion.Text)
+
+		result := newResult(cmd, completion)
+		result.Data = map[string]string{
+			"original_id":       entry.ID,
+			"original_provider": entry.Provider,
+			"original_model":    entry.Model,
+			"original_response": entry.Response,
+		}
+		return emitResult(result)
+	},
+}
+
+// historyPruneCmd representa o comando para remover gerações antigas
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove gerações antigas conforme a retenção configurada",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := commons.LoadConfig()
+		if err != nil {
+			return i18n.Errorf("config.load_error", err)
+		}
+
+		// As flags têm prioridade sobre a configuração
+		days := config.HistoryRetentionDays
+		if cmd.Flags().Changed("days") {
+			days = pruneDays
+		}
+		maxEntries := config.HistoryMaxEntries
+		if cmd.Flags().Changed("keep") {
+			maxEntries = pruneMaxEntries
+		}
+		if days < 0 || maxEntries < 0 {
+			return errors.New(i18n.T("history.negative_retention"))
+		}
+
+		removed, err := history.Prune(time.Duration(days)*24*time.Hour, maxEntries)
+		if err != nil {
+			return err
+		}
+
+		output.Progress(i18n.T("history.pruned", removed))
+
+		result := newResult(cmd, nil)
+		result.Data = map[string]int{"removed": removed}
+		return emitResult(result)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+
+	// Adiciona os subcomandos
+	historyCmd.AddCommand(historyListCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyRerunCmd)
+	historyCmd.AddCommand(historyPruneCmd)
+
+	// Flags para o comando list
+	historyListCmd.Flags().IntVarP(&historyLimit, "limit", "l", 20, "Número máximo de gerações a listar (0 para todas)")
+	historyListCmd.Flags().StringVarP(&historyCommand, "command", "c", "", "Filtrar pelo comando (ex: commit, pr)")
+
+	// Flags para o comando rerun
+	historyRerunCmd.Flags().StringVarP(&rerunProvider, "provider", "p", "", "Provedor de IA a ser usado (padrão: o configurado)")
+	historyRerunCmd.Flags().StringVarP(&rerunModel, "model", "m", "", "Modelo de IA a ser usado (padrão: o do provedor)")
+
+	// Flags para o comando prune
+	historyPruneCmd.Flags().IntVarP(&pruneDays, "days", "d", 0, "Remove gerações mais antigas que este número de dias")
+	historyPruneCmd.Flags().IntVarP(&pruneMaxEntries, "keep", "k", 0, "Mantém apenas este número de gerações mais recentes")
+}
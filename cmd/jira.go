@@ -8,6 +8,9 @@ import (
 	"gojira/services"
 	"gojira/services/ai"
 	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 	"strings"
 )
 
@@ -24,11 +27,11 @@ var jiraCmd = &cobra.Command{
 	Short: "Gera descrições para tarefas do Jira",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if taskType != "EPICO" && taskType != "BUG" && taskType != "TASK" {
-			return errors.New("tipo de tarefa inválido. Use EPICO, BUG ou TASK")
+			return errors.New(i18n.T("jira.invalid_type"))
 		}
 
 		if title == "" {
-			return errors.New("o título da tarefa não pode estar vazio")
+			return errors.New(i18n.T("jira.empty_title"))
 		}
 
 		model := commons.GetModel(taskType)
@@ -36,33 +39,27 @@ var jiraCmd = &cobra.Command{
 		prompt := fmt.Sprintf("Crie uma descrição detalhada de uma tarefa do tipo %s com o título '%s'. %s "+
 			"Baseando-se no modelo: %s os testes e informações para o time de infra são opcionais",
 			strings.ToUpper(taskType), title, briefDesc, model)
+		prompt += i18n.PromptInstruction(i18n.Portuguese)
 
-		// Carrega configuração
-		config, err := commons.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
-		}
-
-		// Obtém o provedor de IA configurado
-		provider, exists := ai.GetProvider(config.AIProvider)
-		if !exists {
-			provider = ai.GetDefaultProvider()
-		}
-
-		response, err := provider.GetCompletions(prompt, config.AIModel)
+		completion, err := ai.Complete(prompt)
 		if err != nil {
 			return err
 		}
+		response := completion.Text
 
-		fmt.Println("\nDescrição gerada:")
-		fmt.Println(response)
+		output.Print(i18n.T("jira.generated"))
+		output.Print(response)
 
-		// Copia para o clipboard
-		err = clipboard.WriteAll(response)
-		if err != nil {
-			return errors.New("não foi possível copiar para o clipboard")
+		// Copia para o clipboard (não faz sentido quando a saída é consumida por scripts)
+		if !output.IsJSON() && !dryrun.Skip(i18n.T("dryrun.clipboard")) {
+			err = clipboard.WriteAll(response)
+			if err != nil {
+				return errors.New(i18n.T("clipboard.error"))
+			}
+			fmt.Println(i18n.T("jira.copied"))
 		}
-		fmt.Println("\nA descrição foi copiada para o clipboard!")
+
+		result := newResult(cmd, completion)
 
 		// Se o projeto estiver especificado, cria a tarefa no Jira
 		if projectKey != "" {
@@ -83,15 +80,18 @@ var jiraCmd = &cobra.Command{
 				ProjectKey:  projectKey,
 			}
 
-			issueKey, err := services.CreateJiraIssue(issue)
-			if err != nil {
-				fmt.Printf("\nAtenção: Não foi possível criar a tarefa no Jira: %v\n", err)
-			} else {
-				fmt.Printf("\nTarefa criada no Jira com sucesso: %s\n", issueKey)
+			if !dryrun.Skip(i18n.T("dryrun.create_issue", issueType, projectKey, title)) {
+				issueKey, err := services.CreateJiraIssue(issue)
+				if err != nil {
+					output.Progress(i18n.T("jira.create_warning", err))
+				} else {
+					output.Progress(i18n.T("jira.created", issueKey))
+					result.IssueKey = issueKey
+				}
 			}
 		}
 
-		return nil
+		return emitResult(result)
 	},
 }
 
@@ -104,4 +104,4 @@ func init() {
 	jiraCmd.Flags().StringVarP(&projectKey, "project", "p", "", "Chave do projeto no Jira (opcional)")
 
 	_ = jiraCmd.MarkFlagRequired("title")
-}
\ No newline at end of file
+}
@@ -0,0 +1,342 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"gojira/functions"
+	"gojira/services/ai"
+	"gojira/utils/conventional"
+	"gojira/utils/dryrun"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	// Flags para o comando lint commits
+	lintMessageFile   string
+	lintInstallHook   bool
+	lintFix           bool
+	lintRequireTicket bool
+	lintMaxLength     int
+)
+
+// hookMarker identifica o hook commit-msg instalado pelo gojira
+const hookMarker = "# gojira commit-msg hook"
+
+// lintCmd representa o comando para validar convenções do projeto
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Valida convenções do projeto",
+	Long:  `Valida se o repositório segue as convenções usadas pelo Gojira, como o formato das mensagens de commit.`,
+}
+
+// lintCommitsCmd representa o comando para validar mensagens de commit
+var lintCommitsCmd = &cobra.Command{
+	Use:   "commits [intervalo]",
+	Short: "Valida as mensagens de commit no formato type: [TICKET] Message",
+	Long: `Valida as mensagens de commit contra o formato gerado pelo gojira commit: tipo permitido, ticket no padrão [A-Z]{2,}-\d+ entre colchetes, tamanho da primeira linha e corpo em itens iniciados por "- ".
+
+Sem intervalo, valida os commits ainda não enviados ao upstream da branch (ou, sem upstream, os desde a branch padrão). Um intervalo como v1.2.0..HEAD ou uma referência inicial também são aceitos.
+
+Com --message-file, valida uma única mensagem, como no hook commit-msg instalado por --install-hook. Com --fix, a IA reescreve as mensagens fora do padrão dos commits ainda não enviados.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Mensagens fora do padrão não são erros de uso; o erro é exibido uma vez, por Execute
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		rules := conventional.Rules{MaxHeaderLength: lintMaxLength, RequireTicket: lintRequireTicket}
+
+		if lintInstallHook {
+			return installCommitHook(cmd)
+		}
+		if lintMessageFile != "" {
+			if lintFix || len(args) > 0 {
+				return errors.New(i18n.T("lint.message_file_exclusive"))
+			}
+			return lintMessage(cmd, rules)
+		}
+
+		gitCmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+		if err := gitCmd.Run(); err != nil {
+			return errors.New(i18n.T("git.must_be_repo"))
+		}
+
+		from, to, err := lintRange(args)
+		if err != nil {
+			return err
+		}
+		logs, err := git.GetCommits(from, to)
+		if err != nil {
+			return err
+		}
+		if len(logs) == 0 {
+			output.Print(i18n.T("lint.no_commits", releaseRange(from, to)))
+			result := newResult(cmd, nil)
+			result.Data = []*commitLint{}
+			return emitResult(result)
+		}
+
+		reports := []*commitLint{}
+		for i := len(logs) - 1; i >= 0; i-- {
+			reports = append(reports, lintCommit(logs[i], rules))
+		}
+
+		var generation *ai.Completion
+		if lintFix {
+			generation, err = fixCommits(reports, to, rules)
+			if err != nil {
+				return err
+			}
+		}
+
+		failed := printCommitLint(reports)
+		result := newResult(cmd, generation)
+		result.Text = ""
+		result.Data = reports
+		if failed > 0 {
+			return failWithResult(result, i18n.Errorf("lint.failed", failed, len(reports)))
+		}
+		return emitResult(result)
+	},
+}
+
+// commitLint representa o resultado da validação de um commit
+type commitLint struct {
+	Hash     string                 `json:"hash"`
+	Header   string                 `json:"header"`
+	Message  string                 `json:"-"`
+	Skipped  bool                   `json:"skipped,omitempty"`
+	Problems []conventional.Problem `json:"problems"`
+	Fixed    string                 `json:"fixed,omitempty"`
+}
+
+// lintCommit valida a mensagem de um commit
+func lintCommit(commit *git.Commit, rules conventional.Rules) *commitLint {
+	report := &commitLint{
+		Hash:     commit.Hash,
+		Header:   strings.SplitN(commit.Message, "\n", 2)[0],
+		Message:  commit.Message,
+		Problems: []conventional.Problem{},
+	}
+	if conventional.Skip(commit.Message) {
+		report.Skipped = true
+		return report
+	}
+	report.Problems = conventional.Lint(commit.Message, rules)
+	return report
+}
+
+// lintRange resolve o intervalo de commits: o informado, os ainda não enviados ao upstream ou, sem
+// upstream, os desde o merge-base com a branch padrão
+func lintRange(args []string) (string, string, error) {
+	if len(args) == 1 {
+		from, to, found := strings.Cut(args[0], "..")
+		if !found {
+			return args[0], "HEAD", nil
+		}
+		if to == "" {
+			to = "HEAD"
+		}
+		return strings.TrimPrefix(from, "."), to, nil
+	}
+
+	if _, err := git.ResolveCommit("@{upstream}"); err == nil {
+		return "@{upstream}", "HEAD", nil
+	}
+	defaultBranch, err := git.GetDefaultBranch()
+	if err != nil {
+		return "", "", err
+	}
+	mergeBase, err := git.GetMergeBase(git.BaseRef(defaultBranch), "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+	return mergeBase, "HEAD", nil
+}
+
+// fixCommits pede à IA uma nova mensagem para cada commit fora do padrão ainda não enviado e reescreve
+// esses commits. Commits já enviados não são alterados, para não reescrever o histórico compartilhado.
+func fixCommits(reports []*commitLint, to string, rules conventional.Rules) (*ai.Completion, error) {
+	head, err := git.ResolveCommit("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	if target, err := git.ResolveCommit(to); err != nil || target != head {
+		return nil, errors.New(i18n.T("lint.fix_head"))
+	}
+	unpushed, err := git.GetUnpushedCommits()
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := ""
+	if branch, err := git.GetBranchName(); err == nil {
+		ticket = git.ParseTicketKey(branch)
+	}
+
+	var generation *ai.Completion
+	messages := map[string]string{}
+	candidates := 0
+	for _, report := range reports {
+		if len(report.Problems) == 0 {
+			continue
+		}
+		if !unpushed[report.Hash] {
+			output.Progress(i18n.T("lint.fix_pushed", report.Hash[:7]))
+			continue
+		}
+
+		problems := []string{}
+		for _, problem := range report.Problems {
+			problems = append(problems, problem.Message)
+		}
+		stat, _ := exec.Command("git", "show", "--stat", "--format=", report.Hash).Output()
+
+		candidates++
+		output.Progress(i18n.T("lint.fixing", report.Hash[:7]))
+		generation, err = functions.FixCommitMessage(report.Message, problems, string(stat), ticket, rules.MaxHeaderLength)
+		if err != nil {
+			return nil, i18n.Errorf("lint.fix_error", report.Hash[:7], err)
+		}
+		if generation.DryRun {
+			continue
+		}
+
+		// Uma sugestão que ainda não segue o padrão é descartada
+		if remaining := conventional.Lint(generation.Text, rules); len(remaining) > 0 {
+			output.Progress(i18n.T("lint.fix_invalid", report.Hash[:7], remaining[0].Message))
+			continue
+		}
+		messages[report.Hash] = generation.Text
+	}
+
+	// No --dry-run, as mensagens não são geradas e a reescrita só é registrada
+	if dryrun.IsEnabled() {
+		if candidates > 0 {
+			dryrun.Skip(i18n.T("dryrun.rewrite_commits", candidates))
+		}
+		return generation, nil
+	}
+	if len(messages) == 0 {
+		return generation, nil
+	}
+	newHead, err := git.RewriteMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range reports {
+		if message, found := messages[report.Hash]; found {
+			report.Fixed = strings.SplitN(message, "\n", 2)[0]
+			report.Problems = []conventional.Problem{}
+		}
+	}
+	output.Progress(i18n.T("lint.fixed", len(messages), newHead[:7]))
+	return generation, nil
+}
+
+// printCommitLint exibe o resultado de cada commit e retorna quantos estão fora do padrão
+func printCommitLint(reports []*commitLint) int {
+	failed := 0
+	for _, report := range reports {
+		short := report.Hash[:7]
+		switch {
+		case report.Skipped:
+			output.Print(fmt.Sprintf("- %s %s %s", short, report.Header, i18n.T("lint.skipped")))
+		case report.Fixed != "":
+			output.Print(fmt.Sprintf("✎ %s %s → %s", short, report.Header, report.Fixed))
+		case len(report.Problems) == 0:
+			output.Print(fmt.Sprintf("✓ %s %s", short, report.Header))
+		default:
+			failed++
+			output.Print(fmt.Sprintf("✗ %s %s", short, report.Header))
+			for _, problem := range report.Problems {
+				output.Print(fmt.Sprintf("    %s (%s)", problem.Message, problem.Rule))
+			}
+		}
+	}
+	return failed
+}
+
+// lintMessage valida a mensagem de um arquivo, no modo usado pelo hook commit-msg
+func lintMessage(cmd *cobra.Command, rules conventional.Rules) error {
+	raw, err := os.ReadFile(lintMessageFile)
+	if err != nil {
+		return i18n.Errorf("lint.message_read_error", lintMessageFile, err)
+	}
+	message := conventional.CleanMessage(string(raw))
+
+	result := newResult(cmd, nil)
+	problems := []conventional.Problem{}
+	if message != "" && !conventional.Skip(message) {
+		problems = conventional.Lint(message, rules)
+	}
+	result.Data = map[string]interface{}{"message": message, "problems": problems}
+	if len(problems) == 0 {
+		return emitResult(result)
+	}
+
+	output.Progress(i18n.T("lint.message_invalid", strings.SplitN(message, "\n", 2)[0]))
+	for _, problem := range problems {
+		output.Progress(fmt.Sprintf("    %s (%s)", problem.Message, problem.Rule))
+	}
+	output.Progress(i18n.T("lint.message_hint"))
+	return failWithResult(result, errors.New(i18n.T("lint.message_failed")))
+}
+
+// installCommitHook instala o hook commit-msg que valida cada nova mensagem com as regras atuais.
+// Um hook existente só é substituído se tiver sido instalado pelo gojira.
+func installCommitHook(cmd *cobra.Command) error {
+	dir, err := git.GetHooksDir()
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, "commit-msg")
+	if existing, err := os.ReadFile(path); err == nil && !strings.Contains(string(existing), hookMarker) {
+		return i18n.Errorf("lint.hook_exists", path)
+	}
+
+	// O hook usa o gojira do PATH quando houver, para funcionar em outras máquinas da equipe
+	executable := "gojira"
+	if _, err := exec.LookPath(executable); err != nil {
+		if self, err := os.Executable(); err == nil {
+			executable = self
+		}
+	}
+	command := fmt.Sprintf("%q lint commits --message-file \"$1\" --max-length %d", executable, lintMaxLength)
+	if lintRequireTicket {
+		command += " --require-ticket"
+	}
+	script := "#!/bin/sh\n" + hookMarker + "\nexec " + command + "\n"
+
+	result := newResult(cmd, nil)
+	if !dryrun.Skip(i18n.T("dryrun.write_file", path)) {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return i18n.Errorf("lint.hook_error", path, err)
+		}
+		if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+			return i18n.Errorf("lint.hook_error", path, err)
+		}
+		output.Progress(i18n.T("lint.hook_installed", path))
+		result.Files = []string{path}
+	}
+	return emitResult(result)
+}
+
+func init() {
+	RootCmd.AddCommand(lintCmd)
+	lintCmd.AddCommand(lintCommitsCmd)
+
+	// Flags para o comando lint commits
+	lintCommitsCmd.Flags().StringVar(&lintMessageFile, "message-file", "", "Validar a mensagem do arquivo (modo do hook commit-msg)")
+	lintCommitsCmd.Flags().BoolVar(&lintInstallHook, "install-hook", false, "Instalar o hook commit-msg que valida cada nova mensagem")
+	lintCommitsCmd.Flags().BoolVar(&lintFix, "fix", false, "Reescrever com a IA as mensagens fora do padrão dos commits ainda não enviados")
+	lintCommitsCmd.Flags().BoolVar(&lintRequireTicket, "require-ticket", false, "Exigir o ticket [ABC-123] na primeira linha")
+	lintCommitsCmd.Flags().IntVar(&lintMaxLength, "max-length", conventional.DefaultMaxHeaderLength, "Tamanho máximo da primeira linha")
+}
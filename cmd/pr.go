@@ -1,12 +1,24 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
+	"gojira/functions"
+	"gojira/services"
 	"gojira/services/ai"
+	"gojira/services/codehost"
 	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 )
@@ -19,6 +31,16 @@ var (
 	prRemote      string
 	prBaseBranch  string
 	prDraft       bool
+	prReviewers   []string
+	prLabels      []string
+	prAssignees   []string
+	prMilestone   string
+	prTemplate    string
+	prTransition  string
+	prNoJira      bool
+	prFullDiff    bool
+	prBudget      int
+	prParallel    int
 )
 
 // prCmd representa o comando para criar um pull request
@@ -30,45 +52,120 @@ var prCmd = &cobra.Command{
 		// Verifica se estamos em um repositório git
 		gitCmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
 		if err := gitCmd.Run(); err != nil {
-			return fmt.Errorf("este comando deve ser executado dentro de um repositório Git")
+			return errors.New(i18n.T("git.must_be_repo"))
+		}
+
+		if prFullDiff && prBudget <= 0 {
+			return errors.New(i18n.T("pr.invalid_budget"))
 		}
 
 		// Obtém a branch atual se não foi especificada
 		if prBranch == "" {
 			branchCmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
-			output, err := branchCmd.Output()
+			branchOutput, err := branchCmd.Output()
 			if err != nil {
-				return fmt.Errorf("erro ao obter o nome da branch atual: %w", err)
+				return i18n.Errorf("dev.current_branch_error", err)
 			}
-			prBranch = strings.TrimSpace(string(output))
+			prBranch = strings.TrimSpace(string(branchOutput))
 		}
 
+		// Sem --base, usa a branch padrão do repositório (origin/HEAD, o remoto ou a configuração)
+		if prBaseBranch == "" {
+			defaultBranch, err := git.GetDefaultBranch()
+			if err != nil {
+				return err
+			}
+			prBaseBranch = defaultBranch
+		}
+
+		// Com um ticket na branch e o Jira configurado, a issue é ligada ao PR
+		issue, issueURL := prJiraIssue(prBranch)
+
+		// Guarda a última geração para informar provedor e modelo no resultado
+		var generation *ai.Completion
+
 		// Se não foi fornecido um título, gera um baseado nas alterações
 		if prTitle == "" {
-			fmt.Println("Gerando título para o PR...")
+			output.Progress(i18n.T("pr.generating_title"))
 			var err error
-			prTitle, err = generatePRTitle(prBranch)
+			generation, err = generatePRTitle(prBranch, prBaseBranch)
 			if err != nil {
-				return fmt.Errorf("erro ao gerar título do PR: %w", err)
+				return i18n.Errorf("pr.title_error", err)
 			}
-			fmt.Printf("Título gerado: %s\n", prTitle)
+			prTitle = generation.Text
+			output.Progress(i18n.T("pr.title_generated", prTitle))
 		}
 
-		// Se não foi fornecida uma descrição, gera uma baseada nas alterações
+		// Se não foi fornecida uma descrição, gera uma baseada nas alterações, seguindo o modelo do repositório se houver
 		if prDescription == "" {
-			fmt.Println("Gerando descrição para o PR...")
-			var err error
-			prDescription, err = generatePRDescription(prBranch, prBaseBranch)
+			template, err := choosePRTemplate()
 			if err != nil {
-				return fmt.Errorf("erro ao gerar descrição do PR: %w", err)
+				return err
 			}
-			fmt.Println("Descrição gerada.")
+			if template != nil {
+				output.Progress(i18n.T("pr.template_using", template.Path))
+			}
+
+			output.Progress(i18n.T("pr.generating_description"))
+			generation, err = generatePRDescription(prBranch, prBaseBranch, template)
+			if err != nil {
+				return i18n.Errorf("pr.description_error", err)
+			}
+			prDescription = generation.Text
+			output.Progress(i18n.T("pr.description_generated"))
+		}
+
+		// O link e o resumo da issue abrem a descrição; ao atualizar um PR, o cabeçalho não é repetido
+		if issue != nil && !strings.Contains(prDescription, issueURL) {
+			prDescription = fmt.Sprintf("**Jira:** [%s](%s) - %s\n\n", issue.Key, issueURL, issue.Summary) + prDescription
+		}
+
+		// Com a plataforma identificada pelo remoto (ou pela configuração) e um token, o PR é criado
+		// pela API, sem depender do gh ou do glab
+		config, err := commons.LoadConfig()
+		if err != nil {
+			return i18n.Errorf("config.load_error", err)
+		}
+		kind := ""
+		remote, err := prTarget()
+		if err == nil {
+			kind = codehost.Detect(config, remote)
+		}
+		token := codehost.Token(config, kind, remote)
+		useAPI := kind != "" && token != ""
+
+		// Com --dry-run, o PR não é criado; o resultado informa a plataforma e se a API seria usada
+		if dryrun.Skip(i18n.T("dryrun.create_pr", prTitle, prBranch, prBaseBranch)) {
+			linkJiraIssue(issue, "")
+			via := "cli"
+			if useAPI {
+				via = "api"
+			}
+			result := newResult(cmd, generation)
+			result.Text = prDescription
+			result.Data = map[string]string{
+				"title":     prTitle,
+				"branch":    prBranch,
+				"base":      prBaseBranch,
+				"jira":      jiraKey(issue),
+				"code_host": kind,
+				"via":       via,
+			}
+			return emitResult(result)
+		}
+
+		if useAPI {
+			host, err := codehost.New(config, kind, token, remote)
+			if err != nil {
+				return err
+			}
+			return createHostedPR(cmd, generation, host, remote, issue)
 		}
 
 		// Salva a descrição em um arquivo temporário
 		descFile := "pr-description.md"
 		if err := os.WriteFile(descFile, []byte(prDescription), 0644); err != nil {
-			return fmt.Errorf("erro ao salvar a descrição do PR: %w", err)
+			return i18n.Errorf("pr.description_save_error", err)
 		}
 		defer os.Remove(descFile)
 
@@ -90,6 +187,7 @@ var prCmd = &cobra.Command{
 			if prDraft {
 				cmdArgs = append(cmdArgs, "--draft")
 			}
+			cmdArgs = append(cmdArgs, prMetadataArgs()...)
 		} else {
 			cmdExists, _ = exec.LookPath("glab")
 			if cmdExists != "" {
@@ -101,24 +199,213 @@ var prCmd = &cobra.Command{
 				if prDraft {
 					cmdArgs = append(cmdArgs, "--draft")
 				}
+				cmdArgs = append(cmdArgs, prMetadataArgs()...)
+			} else if kind != "" && !codehost.Trusted(config, kind, remote) {
+				// O host só foi reconhecido pelo nome, e o token não é enviado a ele sem configuração
+				return i18n.Errorf("pr.untrusted_host", remote.Host, kind)
+			} else if kind != "" {
+				// A plataforma é conhecida, mas falta o token para usar a API
+				return i18n.Errorf("pr.missing_token", kind, codehost.TokenVariable(kind))
 			} else {
-				// Fallback para git push se nenhuma CLI estiver disponível
-				return fmt.Errorf("não foi encontrado 'gh' (GitHub CLI) ou 'glab' (GitLab CLI). Instale uma dessas ferramentas para criar PRs")
+				return errors.New(i18n.T("pr.no_cli"))
 			}
 		}
 
-		// Executa o comando
-		fmt.Printf("Criando PR usando %s...\n", cmdArgs[0])
+		// Executa o comando, guardando a saída para extrair a URL do PR
+		output.Progress(i18n.T("pr.creating", cmdArgs[0]))
+		var cliOutput bytes.Buffer
 		prCmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-		prCmd.Stdout = os.Stdout
+		prCmd.Stdout = io.MultiWriter(output.ProgressWriter(), &cliOutput)
 		prCmd.Stderr = os.Stderr
 		prCmd.Stdin = os.Stdin
-		return prCmd.Run()
+		if err := prCmd.Run(); err != nil {
+			return err
+		}
+
+		prURL := extractURL(cliOutput.String())
+		linkJiraIssue(issue, prURL)
+
+		result := newResult(cmd, generation)
+		result.Text = prDescription
+		result.Data = map[string]string{
+			"title":  prTitle,
+			"branch": prBranch,
+			"base":   prBaseBranch,
+			"url":    prURL,
+			"jira":   jiraKey(issue),
+		}
+		return emitResult(result)
 	},
 }
 
+// prTarget retorna o repositório de destino do PR: o do remoto origin ou, com --remote, o
+// repositório owner/repo informado no mesmo host do origin (github.com se não houver origin)
+func prTarget() (*git.Remote, error) {
+	origin, err := git.GetRemote("origin")
+	if prRemote == "" {
+		return origin, err
+	}
+
+	index := strings.LastIndex(prRemote, "/")
+	if index <= 0 || index == len(prRemote)-1 {
+		return nil, i18n.Errorf("pr.invalid_remote", prRemote)
+	}
+	host := "github.com"
+	if err == nil {
+		host = origin.Host
+	}
+	return &git.Remote{Host: host, Owner: prRemote[:index], Name: prRemote[index+1:]}, nil
+}
+
+// prHeadOwner retorna o dono do repositório de onde a branch é enviada: o do origin, que é o fork
+// quando o PR é aberto com --remote no repositório original
+func prHeadOwner(target *git.Remote) string {
+	if origin, err := git.GetRemote("origin"); err == nil && origin.Host == target.Host {
+		return origin.Owner
+	}
+	return target.Owner
+}
+
+// createHostedPR cria o PR pela API da plataforma ou atualiza o que já estiver aberto para a branch
+func createHostedPR(cmd *cobra.Command, generation *ai.Completion, host codehost.CodeHost, remote *git.Remote, issue *services.JiraIssue) error {
+	output.Progress(i18n.T("pr.creating_api", host.Name(), remote.Path()))
+	pull, err := host.CreateOrUpdate(&codehost.PullRequest{
+		Title:     prTitle,
+		Body:      prDescription,
+		Head:      prBranch,
+		HeadOwner: prHeadOwner(remote),
+		Base:      prBaseBranch,
+		Draft:     prDraft,
+		Reviewers: prReviewers,
+		Labels:    prLabels,
+		Assignees: prAssignees,
+		Milestone: prMilestone,
+	})
+
+	// O PR pode ter sido criado mesmo que a definição de revisores, labels ou milestone tenha falhado
+	if pull != nil {
+		if pull.Updated {
+			output.Progress(i18n.T("pr.updated", pull.Number, pull.URL))
+		} else {
+			output.Progress(i18n.T("pr.created", pull.Number, pull.URL))
+		}
+	}
+	if err != nil {
+		return i18n.Errorf("pr.api_error", err)
+	}
+	linkJiraIssue(issue, pull.URL)
+
+	result := newResult(cmd, generation)
+	result.Text = prDescription
+	result.Data = map[string]interface{}{
+		"title":   prTitle,
+		"branch":  prBranch,
+		"base":    prBaseBranch,
+		"url":     pull.URL,
+		"number":  pull.Number,
+		"updated": pull.Updated,
+		"jira":    jiraKey(issue),
+	}
+	return emitResult(result)
+}
+
+// prJiraIssue busca a issue do ticket da branch. Sem ticket, com --no-jira ou sem o Jira configurado,
+// retorna nil; uma falha ao buscar a issue é só avisada, para não impedir o PR.
+func prJiraIssue(branch string) (*services.JiraIssue, string) {
+	key := git.ParseTicketKey(branch)
+	if key == "" || prNoJira {
+		return nil, ""
+	}
+	issueURL, err := services.JiraIssueURL(key)
+	if err != nil {
+		return nil, ""
+	}
+
+	issue, err := services.GetJiraIssue(key)
+	if err != nil {
+		output.Progress(i18n.T("pr.jira_fetch_warning", key, err))
+		return nil, ""
+	}
+	output.Progress(i18n.T("pr.jira_issue", issue.Key, issue.Summary))
+	return issue, issueURL
+}
+
+// linkJiraIssue adiciona à issue um link remoto para o PR e, com --transition, move a issue. Como o
+// PR já existe nesse ponto, as falhas são apenas avisadas.
+func linkJiraIssue(issue *services.JiraIssue, prURL string) {
+	if issue == nil {
+		return
+	}
+
+	if !dryrun.Skip(i18n.T("dryrun.jira_link", issue.Key)) {
+		if prURL == "" {
+			output.Progress(i18n.T("pr.jira_no_url", issue.Key))
+		} else if err := services.AddJiraRemoteLink(issue.Key, prURL, prTitle); err != nil {
+			output.Progress(i18n.T("pr.jira_link_warning", issue.Key, err))
+		} else {
+			output.Progress(i18n.T("pr.jira_linked", issue.Key))
+		}
+	}
+
+	if prTransition == "" || dryrun.Skip(i18n.T("dryrun.jira_transition", issue.Key, prTransition)) {
+		return
+	}
+	moved, err := services.TransitionJiraIssue(issue.Key, prTransition)
+	switch {
+	case err != nil:
+		output.Progress(i18n.T("pr.jira_transition_warning", issue.Key, err))
+	case moved:
+		output.Progress(i18n.T("pr.jira_transitioned", issue.Key, prTransition))
+	default:
+		output.Progress(i18n.T("pr.jira_already_in_status", issue.Key, prTransition))
+	}
+}
+
+// jiraKey retorna a chave da issue ligada ao PR, ou vazio se não houver
+func jiraKey(issue *services.JiraIssue) string {
+	if issue == nil {
+		return ""
+	}
+	return issue.Key
+}
+
+// prMetadataArgs retorna as flags de revisores, labels, responsáveis e milestone, aceitas igualmente pelo gh e pelo glab
+func prMetadataArgs() []string {
+	args := []string{}
+	for _, reviewer := range prReviewers {
+		args = append(args, "--reviewer", reviewer)
+	}
+	for _, label := range prLabels {
+		args = append(args, "--label", label)
+	}
+	for _, assignee := range prAssignees {
+		args = append(args, "--assignee", assignee)
+	}
+	if prMilestone != "" {
+		args = append(args, "--milestone", prMilestone)
+	}
+	return args
+}
+
+// extractURL retorna a última URL impressa pela CLI da plataforma
+func extractURL(cliOutput string) string {
+	lines := strings.Split(strings.TrimSpace(cliOutput), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(line, "http://") || strings.HasPrefix(line, "https://") {
+			return line
+		}
+	}
+	return ""
+}
+
+// prTitleResponse representa a resposta estruturada da geração do título do PR
+type prTitleResponse struct {
+	Title string `json:"title" description:"Pull Request title, at most 72 characters"`
+}
+
 // generatePRTitle gera um título para o PR baseado nas alterações
-func generatePRTitle(branch string) (string, error) {
+func generatePRTitle(branch, baseBranch string) (*ai.Completion, error) {
 	// Obtém o tipo da branch (feature, bugfix, etc.)
 	branchType := "feature"
 	if strings.HasPrefix(branch, "fix/") || strings.HasPrefix(branch, "bugfix/") || strings.HasPrefix(branch, "hotfix/") {
@@ -130,32 +417,17 @@ func generatePRTitle(branch string) (string, error) {
 	}
 
 	// Extrai o ID do ticket se existir
-	ticketID := ""
-	parts := strings.Split(branch, "/")
-	if len(parts) > 1 {
-		branchName := parts[1]
-		ticketParts := strings.Split(branchName, "-")
-		if len(ticketParts) >= 2 {
-			// Verifica se a primeira parte parece ser um ID de ticket (ex: ABC-123)
-			firstPart := strings.ToUpper(ticketParts[0])
-			if len(firstPart) >= 2 && len(firstPart) <= 5 {
-				if _, err := strconv.Atoi(ticketParts[1]); err == nil {
-					ticketID = firstPart + "-" + ticketParts[1]
-				}
-			}
-		}
-	}
+	ticketID := git.ParseTicketKey(branch)
 
-	// Obtém as alterações desde a branch principal
-	gitCmd := exec.Command("git", "log", "--oneline", "--no-merges", "origin/main.."+branch)
-	output, err := gitCmd.Output()
+	// Obtém as alterações desde o ponto em que a branch se separou da base
+	mergeBase, err := git.GetMergeBase(git.BaseRef(baseBranch), branch)
 	if err != nil {
-		// Se falhar, tenta sem o origin/
-		gitCmd = exec.Command("git", "log", "--oneline", "--no-merges", "main.."+branch)
-		output, err = gitCmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("erro ao obter log de commits: %w", err)
-		}
+		return nil, err
+	}
+	gitCmd := exec.Command("git", "log", "--oneline", "--no-merges", mergeBase+".."+branch)
+	commits, err := gitCmd.Output()
+	if err != nil {
+		return nil, i18n.Errorf("pr.log_error", err)
 	}
 
 	// Constrói o prompt para a IA
@@ -163,67 +435,134 @@ func generatePRTitle(branch string) (string, error) {
 		"Baseado nas seguintes alterações de commit, gere um título conciso e descritivo para um Pull Request. "+
 			"O título deve começar com o tipo '%s' seguido de dois pontos. Se '%s' for um ID de ticket, inclua-o entre colchetes. "+
 			"O título deve ter no máximo 72 caracteres.\n\nCommits:\n%s",
-		branchType, ticketID, string(output),
+		branchType, ticketID, string(commits),
 	)
+	prompt += i18n.PromptInstruction(i18n.Portuguese)
 
-	// Carrega configuração
-	config, err := commons.LoadConfig()
+	// Gera o título
+	var response prTitleResponse
+	title, err := ai.CompleteJSON(prompt, &response)
 	if err != nil {
-		return "", fmt.Errorf("erro ao carregar configuração: %w", err)
+		return nil, i18n.Errorf("pr.title_ai_error", err)
 	}
 
-	// Obtém o provedor de IA configurado
-	provider, exists := ai.GetProvider(config.AIProvider)
-	if !exists {
-		provider = ai.GetDefaultProvider()
+	// Limpa e formata o título, cortando por caracteres e não por bytes
+	if !title.DryRun {
+		title.Text = strings.TrimSpace(response.Title)
+	}
+	if runes := []rune(title.Text); len(runes) > 72 {
+		title.Text = strings.TrimSpace(string(runes[:72]))
 	}
 
-	// Gera o título
-	title, err := provider.GetCompletions(prompt, config.AIModel)
+	return title, nil
+}
+
+// choosePRTemplate encontra o modelo de descrição de PR do repositório. Com vários modelos e sem
+// --template, pergunta qual usar se o terminal for interativo; caso contrário, usa o primeiro.
+func choosePRTemplate() (*functions.PRTemplate, error) {
+	if prTemplate == "none" {
+		return nil, nil
+	}
+
+	root, err := git.GetRepoRoot()
 	if err != nil {
-		return "", fmt.Errorf("erro ao gerar título com IA: %w", err)
+		return nil, err
+	}
+	templates, err := functions.FindPRTemplates(root)
+	if err != nil {
+		return nil, err
 	}
 
-	// Limpa e formata o título
-	title = strings.TrimSpace(title)
-	if len(title) > 72 {
-		title = title[:72]
+	if prTemplate != "" {
+		return functions.SelectPRTemplate(templates, prTemplate)
+	}
+	if len(templates) <= 1 {
+		if len(templates) == 0 {
+			return nil, nil
+		}
+		return templates[0], nil
 	}
 
-	return title, nil
+	names := []string{}
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+	if !isInteractive() {
+		output.Progress(i18n.T("pr.template_multiple", strings.Join(names, ", "), templates[0].Name))
+		return templates[0], nil
+	}
+
+	output.Progress(i18n.T("pr.template_choose"))
+	for i, template := range templates {
+		relative, err := filepath.Rel(root, template.Path)
+		if err != nil {
+			relative = template.Path
+		}
+		output.Progress(fmt.Sprintf("  %d) %s (%s)", i+1, template.Name, relative))
+	}
+	fmt.Fprint(os.Stderr, i18n.T("pr.template_prompt"))
+
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return templates[0], nil
+	}
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(templates) {
+		return functions.SelectPRTemplate(templates, answer)
+	}
+	return templates[choice-1], nil
+}
+
+// isInteractive indica se o comando pode fazer perguntas: a entrada é um terminal e a saída não é JSON
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0 && !output.IsJSON()
 }
 
-// generatePRDescription gera uma descrição detalhada para o PR baseada nas alterações
-func generatePRDescription(branch, baseBranch string) (string, error) {
-	if baseBranch == "" {
-		baseBranch = "main"
+// generatePRDescription gera uma descrição detalhada para o PR baseada nas alterações.
+// Com um modelo do repositório, a IA preenche as seções dele no lugar do formato padrão.
+func generatePRDescription(branch, baseBranch string, template *functions.PRTemplate) (*ai.Completion, error) {
+	// Compara a partir do merge-base, para não incluir o que entrou na base depois da criação da branch
+	mergeBase, err := git.GetMergeBase(git.BaseRef(baseBranch), branch)
+	if err != nil {
+		return nil, err
 	}
 
 	// Obtém a diferença entre as branches
-	gitCmd := exec.Command("git", "diff", "--stat", "origin/"+baseBranch+".."+branch)
+	gitCmd := exec.Command("git", "diff", "--stat", mergeBase+".."+branch)
 	diffStat, err := gitCmd.Output()
 	if err != nil {
-		// Se falhar, tenta sem o origin/
-		gitCmd = exec.Command("git", "diff", "--stat", baseBranch+".."+branch)
-		diffStat, err = gitCmd.Output()
-		if err != nil {
-			return "", fmt.Errorf("erro ao obter diff stat: %w", err)
-		}
+		return nil, i18n.Errorf("pr.diff_stat_error", err)
 	}
 
 	// Obtém a lista de commits
-	gitCmd = exec.Command("git", "log", "--pretty=format:%h - %s (%an)", "--no-merges", "origin/"+baseBranch+".."+branch)
+	gitCmd = exec.Command("git", "log", "--pretty=format:%h - %s (%an)", "--no-merges", mergeBase+".."+branch)
 	commits, err := gitCmd.Output()
 	if err != nil {
-		// Se falhar, tenta sem o origin/
-		gitCmd = exec.Command("git", "log", "--pretty=format:%h - %s (%an)", "--no-merges", baseBranch+".."+branch)
-		commits, err = gitCmd.Output()
+		return nil, i18n.Errorf("pr.commits_error", err)
+	}
+
+	// Com --full-diff, cada arquivo é resumido a partir do próprio diff e os resumos entram no prompt
+	var summary *functions.DiffSummary
+	details := ""
+	if prFullDiff {
+		diffs, err := git.GetRangeDiff(mergeBase, branch)
 		if err != nil {
-			return "", fmt.Errorf("erro ao obter commits: %w", err)
+			return nil, err
 		}
+		output.Progress(i18n.T("pr.summarizing_files", len(diffs), prParallel, prBudget))
+		summary, err = functions.SummarizeDiff(diffs, prBudget, prParallel)
+		if err != nil {
+			return nil, err
+		}
+		details = "\n\nResumo das alterações de cada arquivo:\n" + summary.Text() + "\n" + summary.ImpactInstruction()
 	}
 
 	// Constrói o prompt para a IA
+	if template != nil {
+		return generateTemplateDescription(template, string(diffStat)+details, string(commits), summary)
+	}
 	prompt := fmt.Sprintf(
 		"Crie uma descrição detalhada para um Pull Request baseado nas seguintes alterações. "+
 			"A descrição deve incluir:\n"+
@@ -235,27 +574,49 @@ func generatePRDescription(branch, baseBranch string) (string, error) {
 			"Diferenças de arquivos:\n%s\n\n"+
 			"Commits incluídos:\n%s\n\n"+
 			"Formate a resposta em Markdown. Inclua títulos (##) para cada seção.",
-		string(diffStat), string(commits),
+		string(diffStat)+details, string(commits),
 	)
+	prompt += i18n.PromptInstruction(i18n.Portuguese)
 
-	// Carrega configuração
-	config, err := commons.LoadConfig()
+	// Gera a descrição
+	description, err := ai.Complete(prompt)
 	if err != nil {
-		return "", fmt.Errorf("erro ao carregar configuração: %w", err)
+		return nil, i18n.Errorf("pr.description_ai_error", err)
 	}
-
-	// Obtém o provedor de IA configurado
-	provider, exists := ai.GetProvider(config.AIProvider)
-	if !exists {
-		provider = ai.GetDefaultProvider()
+	if summary != nil && !description.DryRun {
+		description.Text = functions.PreserveImpactSections(description.Text, summary)
 	}
 
-	// Gera a descrição
-	description, err := provider.GetCompletions(prompt, config.AIModel)
+	return description, nil
+}
+
+// generateTemplateDescription pede à IA que preencha o modelo de PR do repositório. Os itens de
+// checklist do modelo que não voltarem na resposta são devolvidos à descrição, desmarcados, assim
+// como as seções de impacto do --full-diff.
+func generateTemplateDescription(template *functions.PRTemplate, diffStat, commits string, summary *functions.DiffSummary) (*ai.Completion, error) {
+	prompt := fmt.Sprintf(
+		"Preencha o modelo de descrição de Pull Request abaixo, usado por este repositório, a partir das alterações. "+
+			"Mantenha todos os títulos, a ordem das seções e a formatação do modelo. Substitua os comentários (<!-- -->) "+
+			"e os textos de instrução pelo conteúdo correspondente; se uma seção não se aplicar, escreva \"N/A\". "+
+			"Mantenha todos os itens de checklist (- [ ]) com o mesmo texto, marcando com [x] apenas os que as alterações "+
+			"comprovadamente atendem. Responda apenas com a descrição em Markdown.\n\n"+
+			"Modelo:\n```markdown\n%s\n```\n\n"+
+			"Diferenças de arquivos:\n%s\n\n"+
+			"Commits incluídos:\n%s",
+		strings.TrimSpace(template.Content), diffStat, commits,
+	)
+	prompt += i18n.PromptInstruction(i18n.Portuguese)
+
+	description, err := ai.Complete(prompt)
 	if err != nil {
-		return "", fmt.Errorf("erro ao gerar descrição com IA: %w", err)
+		return nil, i18n.Errorf("pr.description_ai_error", err)
+	}
+	if !description.DryRun {
+		description.Text = functions.PreserveCheckboxes(description.Text, template.Content)
+		if summary != nil {
+			description.Text = functions.PreserveImpactSections(description.Text, summary)
+		}
 	}
-
 	return description, nil
 }
 
@@ -267,6 +628,16 @@ func init() {
 	prCmd.Flags().StringVarP(&prDescription, "description", "d", "", "Descrição do PR")
 	prCmd.Flags().StringVarP(&prBranch, "branch", "b", "", "Nome da branch de origem (padrão: branch atual)")
 	prCmd.Flags().StringVarP(&prRemote, "remote", "r", "", "Repositório remoto (formato: owner/repo)")
-	prCmd.Flags().StringVarP(&prBaseBranch, "base", "B", "main", "Branch base para o PR")
+	prCmd.Flags().StringVarP(&prBaseBranch, "base", "B", "", "Branch base para o PR (padrão: a branch padrão do repositório)")
 	prCmd.Flags().BoolVarP(&prDraft, "draft", "D", false, "Criar o PR como rascunho")
-}
\ No newline at end of file
+	prCmd.Flags().StringSliceVar(&prReviewers, "reviewer", nil, "Revisores do PR (usuários ou times no formato org/time)")
+	prCmd.Flags().StringSliceVar(&prLabels, "label", nil, "Labels do PR")
+	prCmd.Flags().StringSliceVar(&prAssignees, "assignee", nil, "Responsáveis pelo PR")
+	prCmd.Flags().StringVar(&prMilestone, "milestone", "", "Milestone do PR (título ou número)")
+	prCmd.Flags().StringVar(&prTransition, "transition", "", "Transição ou status do Jira aplicado à issue da branch após criar o PR (ex.: \"Code Review\")")
+	prCmd.Flags().BoolVar(&prNoJira, "no-jira", false, "Não ligar o PR à issue do Jira indicada na branch")
+	prCmd.Flags().BoolVar(&prFullDiff, "full-diff", false, "Resumir o diff de cada arquivo com a IA e usar os resumos na descrição")
+	prCmd.Flags().IntVar(&prBudget, "token-budget", 24000, "Orçamento de tokens dos diffs enviados com --full-diff")
+	prCmd.Flags().IntVar(&prParallel, "parallel", 4, "Número de arquivos resumidos ao mesmo tempo com --full-diff")
+	prCmd.Flags().StringVar(&prTemplate, "template", "", "Modelo de descrição do repositório (nome ou caminho; none para o formato padrão)")
+}
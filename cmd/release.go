@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"gojira/functions"
+	"gojira/services"
+	"gojira/services/ai"
+	"gojira/utils/conventional"
+	"gojira/utils/dryrun"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	// Flags para o comando de release
+	releaseFrom          string
+	releaseTo            string
+	releaseVersion       string
+	releaseChangelog     bool
+	releaseChangelogFile string
+	releaseNoJira        bool
+)
+
+// releaseCmd representa o comando para preparar versões
+var releaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Prepara uma nova versão a partir dos commits",
+	Long:  `Prepara uma nova versão a partir dos Conventional Commits gerados pelo gojira commit: notas de versão, CHANGELOG.md e a sugestão da próxima versão semântica.`,
+}
+
+// releaseNotesCmd representa o comando para gerar as notas de versão
+var releaseNotesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Gera as notas de versão e atualiza o CHANGELOG.md",
+	Long:  `Lê os commits entre --from e --to no formato Conventional Commits, agrupa-os por tipo e escopo, busca no Jira o resumo dos tickets citados e gera notas de versão voltadas para os usuários. Sugere a próxima versão semântica (major com quebras de compatibilidade, minor com feat, patch nos demais casos) e, com --changelog, atualiza o CHANGELOG.md no formato Keep a Changelog.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitCmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+		if err := gitCmd.Run(); err != nil {
+			return errors.New(i18n.T("git.must_be_repo"))
+		}
+
+		// Sem --from, parte da tag mais recente; sem nenhuma tag, considera todo o histórico
+		from := releaseFrom
+		if from == "" {
+			if tag, err := git.GetLatestTag(releaseTo); err == nil {
+				from = tag
+			}
+		}
+		current := ""
+		if from != "" {
+			if tag, err := git.GetLatestTag(from); err == nil {
+				current = tag
+			}
+		}
+
+		logs, err := git.GetCommits(from, releaseTo)
+		if err != nil {
+			return err
+		}
+		commits, ignored := parseReleaseCommits(logs)
+		if len(commits) == 0 {
+			return i18n.Errorf("release.no_commits", releaseRange(from, releaseTo))
+		}
+		if ignored > 0 {
+			output.Progress(i18n.T("release.ignored_commits", ignored))
+		}
+
+		version, bump := conventional.NextVersion(current, commits)
+		if releaseVersion != "" {
+			version = releaseVersion
+		}
+		if current == "" {
+			output.Progress(i18n.T("release.first_version", version))
+		} else {
+			output.Progress(i18n.T("release.version", version, bump, current))
+		}
+
+		tickets := releaseTickets(commits)
+
+		output.Progress(i18n.T("release.generating"))
+		notes, err := ai.Complete(buildReleaseNotesPrompt(version, commits, tickets))
+		if err != nil {
+			return i18n.Errorf("release.error", err)
+		}
+
+		result := newResult(cmd, notes)
+		result.Text = notes.Text
+		result.Data = map[string]interface{}{
+			"from":     from,
+			"to":       releaseTo,
+			"previous": current,
+			"version":  version,
+			"bump":     bump,
+			"commits":  commits,
+			"tickets":  tickets,
+		}
+
+		if releaseChangelog {
+			existing, err := os.ReadFile(releaseChangelogFile)
+			if err != nil && !os.IsNotExist(err) {
+				return i18n.Errorf("release.changelog_read_error", releaseChangelogFile, err)
+			}
+			entry := functions.ChangelogEntry(version, time.Now().Format("2006-01-02"), commits)
+			changelog := functions.UpdateChangelog(string(existing), version, entry)
+			if !dryrun.Skip(i18n.T("dryrun.write_file", releaseChangelogFile)) {
+				if err := os.WriteFile(releaseChangelogFile, []byte(changelog), 0644); err != nil {
+					return i18n.Errorf("release.changelog_write_error", releaseChangelogFile, err)
+				}
+				output.Progress(i18n.T("release.changelog_updated", releaseChangelogFile, version))
+				result.Files = []string{releaseChangelogFile}
+			}
+		}
+
+		output.Print(notes.Text)
+		return emitResult(result)
+	},
+}
+
+// parseReleaseCommits interpreta as mensagens no formato Conventional Commits, do mais antigo para o
+// mais recente, e retorna quantos commits fora do formato foram ignorados
+func parseReleaseCommits(logs []*git.Commit) ([]*conventional.Commit, int) {
+	commits := []*conventional.Commit{}
+	ignored := 0
+	for i := len(logs) - 1; i >= 0; i-- {
+		commit, ok := conventional.Parse(logs[i].Message)
+		if !ok {
+			ignored++
+			continue
+		}
+		commit.Hash = logs[i].Hash
+		commits = append(commits, commit)
+	}
+	return commits, ignored
+}
+
+// releaseTickets busca no Jira o resumo de cada ticket citado nos commits. Sem o Jira configurado ou
+// com --no-jira, nada é buscado; falhas em um ticket são apenas avisadas.
+func releaseTickets(commits []*conventional.Commit) map[string]string {
+	tickets := map[string]string{}
+	if releaseNoJira {
+		return tickets
+	}
+	for _, commit := range commits {
+		if commit.Ticket == "" {
+			continue
+		}
+		if _, seen := tickets[commit.Ticket]; seen {
+			continue
+		}
+		if _, err := services.JiraIssueURL(commit.Ticket); err != nil {
+			return tickets
+		}
+		issue, err := services.GetJiraIssue(commit.Ticket)
+		if err != nil {
+			output.Progress(i18n.T("release.ticket_warning", commit.Ticket, err))
+			tickets[commit.Ticket] = ""
+			continue
+		}
+		tickets[commit.Ticket] = issue.Summary
+	}
+	return tickets
+}
+
+// buildReleaseNotesPrompt monta o prompt das notas de versão com os commits agrupados por tipo e escopo
+func buildReleaseNotesPrompt(version string, commits []*conventional.Commit, tickets map[string]string) string {
+	groups := map[string]map[string][]string{}
+	for _, commit := range commits {
+		group := commit.Type
+		if commit.Breaking {
+			group = "breaking"
+		}
+		if groups[group] == nil {
+			groups[group] = map[string][]string{}
+		}
+		line := commit.Subject
+		if commit.Breaking && commit.BreakingNote() != commit.Subject {
+			line += " (" + commit.BreakingNote() + ")"
+		}
+		if summary := tickets[commit.Ticket]; summary != "" {
+			line += fmt.Sprintf(" [%s: %s]", commit.Ticket, summary)
+		} else if commit.Ticket != "" {
+			line += fmt.Sprintf(" [%s]", commit.Ticket)
+		}
+		groups[group][commit.Scope] = append(groups[group][commit.Scope], line)
+	}
+
+	// Quebras de compatibilidade, novidades e correções vêm primeiro; os demais tipos, em ordem alfabética
+	order := []string{"breaking", "feat", "fix"}
+	others := []string{}
+	for group := range groups {
+		if group != "breaking" && group != "feat" && group != "fix" {
+			others = append(others, group)
+		}
+	}
+	sort.Strings(others)
+
+	var sb strings.Builder
+	for _, group := range append(order, others...) {
+		scopes := groups[group]
+		if len(scopes) == 0 {
+			continue
+		}
+		sb.WriteString("\n" + group + ":\n")
+		names := make([]string, 0, len(scopes))
+		for scope := range scopes {
+			names = append(names, scope)
+		}
+		sort.Strings(names)
+		for _, scope := range names {
+			for _, line := range scopes[scope] {
+				if scope != "" {
+					line = scope + ": " + line
+				}
+				sb.WriteString("- " + line + "\n")
+			}
+		}
+	}
+
+	prompt := fmt.Sprintf(
+		"Escreva as notas da versão %s para os usuários do projeto, a partir dos commits abaixo, agrupados por tipo e escopo. "+
+			"Comece pelas quebras de compatibilidade, explicando o que o usuário precisa fazer, e siga com as novidades e as correções. "+
+			"Use o resumo das issues do Jira entre colchetes para explicar o impacto de cada mudança e cite a chave da issue. "+
+			"Omita mudanças internas sem efeito para quem usa o projeto (chore, test, style, refatorações). "+
+			"Formate a resposta em Markdown, com títulos (##) para cada grupo, sem repetir o número da versão no início.\n\n"+
+			"Commits:\n%s",
+		version, sb.String(),
+	)
+	return prompt + i18n.PromptInstruction(i18n.Portuguese)
+}
+
+// releaseRange descreve o intervalo de commits para as mensagens
+func releaseRange(from, to string) string {
+	if from == "" {
+		return to
+	}
+	return from + ".." + to
+}
+
+func init() {
+	RootCmd.AddCommand(releaseCmd)
+	releaseCmd.AddCommand(releaseNotesCmd)
+
+	// Flags para o comando release notes
+	releaseNotesCmd.Flags().StringVar(&releaseFrom, "from", "", "Commit ou tag inicial, exclusive (padrão: a tag mais recente)")
+	releaseNotesCmd.Flags().StringVar(&releaseTo, "to", "HEAD", "Commit ou tag final")
+	releaseNotesCmd.Flags().StringVar(&releaseVersion, "version", "", "Número da nova versão (padrão: a versão sugerida pelos commits)")
+	releaseNotesCmd.Flags().BoolVar(&releaseChangelog, "changelog", false, "Atualizar o CHANGELOG.md com a nova versão")
+	releaseNotesCmd.Flags().StringVar(&releaseChangelogFile, "changelog-file", "CHANGELOG.md", "Arquivo de changelog atualizado com --changelog")
+	releaseNotesCmd.Flags().BoolVar(&releaseNoJira, "no-jira", false, "Não buscar no Jira o resumo dos tickets citados nos commits")
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"gojira/services/ai"
+	"gojira/utils/dryrun"
+	"gojira/utils/output"
+	"strings"
+)
+
+// commandResult é o objeto emitido no stdout quando a saída é --output json
+type commandResult struct {
+	Command  string         `json:"command"`
+	Text     string         `json:"text,omitempty"`
+	Provider string         `json:"provider,omitempty"`
+	Model    string         `json:"model,omitempty"`
+	Cached   bool           `json:"cached,omitempty"`
+	Usage    *ai.Usage      `json:"usage,omitempty"`
+	IssueKey string         `json:"issue_key,omitempty"`
+	Files    []string       `json:"files,omitempty"`
+	Data     interface{}    `json:"data,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	DryRun   *dryrun.Report `json:"dry_run,omitempty"`
+}
+
+// newResult cria o resultado de um comando a partir de uma geração da IA
+func newResult(cmd *cobra.Command, completion *ai.Completion) *commandResult {
+	result := &commandResult{Command: commandName(cmd)}
+	if completion != nil {
+		result.Text = completion.Text
+		result.Provider = completion.Provider
+		result.Model = completion.Model
+		result.Cached = completion.Cached
+		if !completion.Cached && !completion.DryRun {
+			result.Usage = &completion.Usage
+		}
+	}
+	return result
+}
+
+// commandName retorna o caminho do comando sem o nome do executável (ex: "generate readme")
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// resultError é um erro que leva junto o resultado do comando, para que a saída JSON traga os dados
+// e o erro no mesmo objeto
+type resultError struct {
+	result *commandResult
+	err    error
+}
+
+func (e *resultError) Error() string {
+	return e.err.Error()
+}
+
+// failWithResult encerra o comando com erro sem perder os dados do resultado na saída JSON
+func failWithResult(result *commandResult, err error) error {
+	return &resultError{result: result, err: err}
+}
+
+// emitResult escreve o resultado do comando quando a saída JSON está ativa
+func emitResult(result *commandResult) error {
+	if dryrun.IsEnabled() {
+		result.DryRun = dryrun.GetReport()
+	}
+	return output.Emit(result)
+}
@@ -1,8 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"gojira/services/cache"
+	"gojira/services/history"
 	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -11,27 +18,156 @@ import (
 var (
 	// Version é a versão do aplicativo
 	Version = "dev"
-	
+
 	// RootCmd representa o comando base
 	RootCmd = &cobra.Command{
 		Use:     "gojira",
 		Short:   "Uma ferramenta CLI para integração com Jira e geração de documentação usando IA",
 		Version: Version,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !output.SetFormat(outputMode) {
+				return i18n.Errorf("output.invalid_format", outputMode)
+			}
+			history.SetCommand(commandName(cmd))
+			dryrun.SetEnabled(dryRun)
+			policy.SetShowContext(showContext)
+			if showContext {
+				// A interrupção na fronteira com o provedor é esperada; o resumo é exibido em Execute
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			if hookMode() {
+				return nil
+			}
+			return policy.Init()
+		},
 	}
+
+	// contentLanguage é o idioma do conteúdo gerado para o comando atual
+	contentLanguage string
+
+	// outputMode é o formato de saída dos comandos (text, json)
+	outputMode string
+
+	// noCache desativa o cache de respostas para o comando atual
+	noCache bool
+
+	// showContext lista os arquivos que seriam enviados ao provedor, sem enviá-los
+	showContext bool
+
+	// dryRun exibe os prompts e as ações do comando sem chamar o provedor nem alterar nada
+	dryRun bool
 )
 
 // Execute executa o comando root
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
-		fmt.Println(err)
+	cmd, err := RootCmd.ExecuteC()
+	if policy.Reached() {
+		printContext(cmd)
+		return
+	}
+	if err != nil {
+		if output.IsJSON() {
+			failed := &commandResult{Command: commandName(cmd)}
+			var withResult *resultError
+			if errors.As(err, &withResult) {
+				failed = withResult.result
+			}
+			failed.Error = err.Error()
+			_ = emitResult(failed)
+		} else {
+			fmt.Println(err)
+		}
 		os.Exit(1)
 	}
+
+	if dryrun.IsEnabled() && !output.IsJSON() {
+		printDryRunActions()
+	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
+
+	RootCmd.PersistentFlags().StringVar(&contentLanguage, "lang", "", "Idioma do conteúdo gerado (pt, en)")
+	RootCmd.PersistentFlags().StringVar(&outputMode, "output", output.Text, "Formato de saída (text, json)")
+	RootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Não usar respostas em cache, consultando sempre o provedor de IA")
+	RootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Exibe os prompts e as ações que seriam executadas, sem chamar o provedor de IA nem alterar nada")
+	RootCmd.PersistentFlags().BoolVar(&showContext, "show-context", false, "Lista os arquivos que seriam enviados ao provedor de IA, sem enviá-los")
+}
+
+// printContext lista os arquivos que o comando enviaria ao provedor e os bloqueados pela política
+func printContext(cmd *cobra.Command) {
+	if output.IsJSON() {
+		_ = emitResult(&commandResult{
+			Command: commandName(cmd),
+			Data: map[string]interface{}{
+				"provider": policy.Provider(),
+				"files":    policy.Included(),
+				"denied":   policy.Denied(),
+			},
+		})
+		return
+	}
+
+	if len(policy.Included()) == 0 && len(policy.Denied()) == 0 {
+		fmt.Println(i18n.T("policy.no_files", policy.Provider()))
+		return
+	}
+
+	fmt.Println(i18n.T("policy.files_header", policy.Provider()))
+	for _, file := range policy.Included() {
+		fmt.Printf("  + %s\n", file)
+	}
+	if len(policy.Denied()) > 0 {
+		fmt.Println(i18n.T("policy.denied_header"))
+		for _, file := range policy.Denied() {
+			fmt.Printf("  - %s\n", file)
+		}
+	}
+}
+
+// printDryRunActions lista as ações que o comando teria executado fora do --dry-run
+func printDryRunActions() {
+	actions := dryrun.GetReport().Actions
+	if len(actions) == 0 {
+		fmt.Println(i18n.T("dryrun.no_actions"))
+		return
+	}
+
+	fmt.Println(i18n.T("dryrun.actions_header"))
+	for _, action := range actions {
+		fmt.Printf("  - %s\n", action)
+	}
 }
 
 func initConfig() {
-	commons.LoadEnv()
-}
\ No newline at end of file
+	// Define os idiomas antes de qualquer mensagem ser exibida
+	if config, err := commons.LoadConfig(); err == nil {
+		i18n.SetLanguage(config.GetUILanguage())
+		i18n.SetContentLanguage(config.Language)
+		cache.SetEnabled(!config.CacheDisabled)
+	}
+
+	if noCache {
+		cache.SetEnabled(false)
+	}
+
+	// Com --output json, avisos emitidos durante a inicialização também vão para o stderr
+	output.SetFormat(outputMode)
+
+	if contentLanguage != "" && !i18n.OverrideContentLanguage(contentLanguage) {
+		output.Progress(i18n.T("lang.unsupported", contentLanguage))
+	}
+
+	if !hookMode() {
+		commons.LoadEnv()
+	}
+}
+
+// hookMode indica que o gojira foi chamado pelo hook commit-msg, que roda a cada git commit. A
+// validação da mensagem não usa o provedor de IA, então o .env e a política não são carregados,
+// e nenhum aviso sobre eles aparece no commit.
+func hookMode() bool {
+	return lintMessageFile != ""
+}
@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
 	"gojira/services/ai"
 	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 	"os"
 	"os/exec"
 	"strings"
@@ -29,7 +33,7 @@ var standupCmd = &cobra.Command{
 		// Verifica se estamos em um repositório git
 		gitCmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
 		if err := gitCmd.Run(); err != nil {
-			return fmt.Errorf("este comando deve ser executado dentro de um repositório Git")
+			return errors.New(i18n.T("git.must_be_repo"))
 		}
 
 		// Se o email do usuário não foi especificado, tenta obter o email do git config
@@ -44,54 +48,45 @@ var standupCmd = &cobra.Command{
 		// Coleta informações para o standup
 		activities, err := collectActivities(days, userEmail, teamOnly, issuesOnly)
 		if err != nil {
-			return fmt.Errorf("erro ao coletar atividades: %w", err)
+			return i18n.Errorf("standup.collect_error", err)
 		}
 
 		// Constrói o prompt para a IA
 		prompt := buildStandupPrompt(activities, days)
-
-		// Carrega configuração
-		config, err := commons.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
-		}
-
-		// Obtém o provedor de IA configurado
-		provider, exists := ai.GetProvider(config.AIProvider)
-		if !exists {
-			provider = ai.GetDefaultProvider()
-		}
+		prompt += i18n.PromptInstruction(i18n.Portuguese)
 
 		// Gera o relatório
-		fmt.Println("Gerando relatório de standup...")
-		standupReport, err := provider.GetCompletions(prompt, config.AIModel)
+		output.Progress(i18n.T("standup.generating"))
+		standupReport, err := ai.Complete(prompt)
 		if err != nil {
-			return fmt.Errorf("erro ao gerar relatório: %w", err)
+			return i18n.Errorf("standup.error", err)
 		}
+		result := newResult(cmd, standupReport)
 
 		// Salva o relatório em um arquivo se solicitado
-		if exportFile != "" {
-			if err := os.WriteFile(exportFile, []byte(standupReport), 0644); err != nil {
-				return fmt.Errorf("erro ao salvar o relatório: %w", err)
+		if exportFile != "" && !dryrun.Skip(i18n.T("dryrun.write_file", exportFile)) {
+			if err := os.WriteFile(exportFile, []byte(standupReport.Text), 0644); err != nil {
+				return i18n.Errorf("standup.save_error", err)
 			}
-			fmt.Printf("Relatório salvo em %s\n", exportFile)
+			output.Progress(i18n.T("standup.saved", exportFile))
+			result.Files = []string{exportFile}
 		}
 
 		// Exibe o relatório
-		fmt.Println(standupReport)
-		return nil
+		output.Print(standupReport.Text)
+		return emitResult(result)
 	},
 }
 
 // Estrutura para armazenar atividades coletadas
 type Activities struct {
-	Commits   []string
-	Issues    []string
-	PullReqs  []string
-	UserName  string
-	RepoName  string
-	WorksInJira   bool // Indica se existem integrações com Jira
-	HasIssues     bool // Indica se existem issues no projeto
+	Commits     []string
+	Issues      []string
+	PullReqs    []string
+	UserName    string
+	RepoName    string
+	WorksInJira bool // Indica se existem integrações com Jira
+	HasIssues   bool // Indica se existem issues no projeto
 }
 
 // collectActivities coleta as atividades recentes (commits, issues, etc.)
@@ -136,7 +131,7 @@ func collectActivities(days int, userEmail string, teamOnly, issuesOnly bool) (*
 			// Todos os commits
 			commitCmd = exec.Command("git", "log", "--since="+since, "--format=%h | %an | %s")
 		}
-		
+
 		commitBytes, err := commitCmd.Output()
 		if err == nil {
 			commitLines := strings.Split(strings.TrimSpace(string(commitBytes)), "\n")
@@ -156,7 +151,7 @@ func collectActivities(days int, userEmail string, teamOnly, issuesOnly bool) (*
 		issueListCmd.Stderr = nil
 		if err := issueListCmd.Run(); err == nil {
 			activities.HasIssues = true
-			
+
 			// Coleta issues atribuídas ao usuário ou criadas recentemente
 			issueCmd := exec.Command("gh", "issue", "list", "--limit", "10", "--state", "open")
 			issueBytes, err := issueCmd.Output()
@@ -168,7 +163,7 @@ func collectActivities(days int, userEmail string, teamOnly, issuesOnly bool) (*
 					}
 				}
 			}
-			
+
 			// Coleta PRs abertos
 			prCmd := exec.Command("gh", "pr", "list", "--limit", "5", "--state", "open")
 			prBytes, err := prCmd.Output()
@@ -195,13 +190,13 @@ func collectActivities(days int, userEmail string, teamOnly, issuesOnly bool) (*
 // buildStandupPrompt cria o prompt para a IA gerar o relatório de standup
 func buildStandupPrompt(activities *Activities, days int) string {
 	var sb strings.Builder
-	
+
 	sb.WriteString("Gere um relatório para uma reunião de standup diária com base nas atividades a seguir. ")
 	sb.WriteString("O relatório deve seguir o formato padrão de standup:\n\n")
 	sb.WriteString("1. O que foi feito (últimos " + fmt.Sprintf("%d", days) + " dias)\n")
 	sb.WriteString("2. O que será feito hoje\n")
 	sb.WriteString("3. Existe algum bloqueador?\n\n")
-	
+
 	// Adiciona contexto sobre o usuário e projeto
 	if activities.UserName != "" {
 		sb.WriteString("Usuário: " + activities.UserName + "\n")
@@ -209,7 +204,7 @@ func buildStandupPrompt(activities *Activities, days int) string {
 	if activities.RepoName != "" {
 		sb.WriteString("Projeto: " + activities.RepoName + "\n\n")
 	}
-	
+
 	// Adiciona commits
 	if len(activities.Commits) > 0 {
 		sb.WriteString("## Commits recentes:\n\n")
@@ -218,7 +213,7 @@ func buildStandupPrompt(activities *Activities, days int) string {
 		}
 		sb.WriteString("\n")
 	}
-	
+
 	// Adiciona issues
 	if len(activities.Issues) > 0 {
 		sb.WriteString("## Issues abertas:\n\n")
@@ -227,7 +222,7 @@ func buildStandupPrompt(activities *Activities, days int) string {
 		}
 		sb.WriteString("\n")
 	}
-	
+
 	// Adiciona PRs
 	if len(activities.PullReqs) > 0 {
 		sb.WriteString("## Pull Requests abertos:\n\n")
@@ -236,31 +231,31 @@ func buildStandupPrompt(activities *Activities, days int) string {
 		}
 		sb.WriteString("\n")
 	}
-	
+
 	// Dicas para o modelo baseadas no contexto
 	sb.WriteString("Com base nessas informações, gere um relatório conciso e informativo para um standup. ")
 	sb.WriteString("Infira as tarefas atuais e planejadas dos commits e issues. ")
-	
+
 	if activities.WorksInJira {
 		sb.WriteString("O usuário trabalha com Jira, então inclua referências a tickets do Jira se identificados nos commits. ")
 	}
-	
+
 	if !activities.HasIssues && len(activities.Commits) == 0 {
 		sb.WriteString("Não há muitas informações disponíveis, então faça suposições razoáveis sobre o trabalho baseado no nome do projeto. ")
 	}
-	
+
 	sb.WriteString("\nFormate o relatório de forma limpa e profissional. Use listas com marcadores para facilitar a leitura.")
-	
+
 	return sb.String()
 }
 
 func init() {
 	RootCmd.AddCommand(standupCmd)
-	
+
 	// Flags para o comando standup
 	standupCmd.Flags().IntVarP(&days, "days", "d", 1, "Número de dias para incluir no relatório")
 	standupCmd.Flags().StringVarP(&userEmail, "email", "e", "", "Email do usuário para filtrar as atividades (padrão: email do git config)")
 	standupCmd.Flags().BoolVarP(&teamOnly, "team", "t", false, "Incluir atividades de toda a equipe, não apenas do usuário")
 	standupCmd.Flags().BoolVarP(&issuesOnly, "issues", "i", false, "Focar apenas em issues, ignorando commits")
-	standupCmd.Flags().StringVarP(&exportFile, "output", "o", "", "Arquivo para salvar o relatório (opcional)")
-}
\ No newline at end of file
+	standupCmd.Flags().StringVarP(&exportFile, "output-file", "o", "", "Arquivo para salvar o relatório (opcional)")
+}
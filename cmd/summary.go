@@ -1,13 +1,18 @@
 package cmd
 
 import (
-	"fmt"
+	"errors"
 	"github.com/spf13/cobra"
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -30,24 +35,25 @@ var summaryCmd = &cobra.Command{
 		// Verifica se estamos em um repositório git
 		gitCmd := exec.Command("git", "rev-parse", "--is-inside-work-tree")
 		if err := gitCmd.Run(); err != nil {
-			return fmt.Errorf("este comando deve ser executado dentro de um repositório Git")
+			return errors.New(i18n.T("git.must_be_repo"))
 		}
 
-		// Se a base não foi especificada, usa HEAD~10 (10 commits atrás)
+		// Se a base não foi especificada, usa o ponto em que a branch atual se separou da branch padrão.
+		// Na própria branch padrão não há divergência, então usa HEAD~10 (10 commits atrás)
 		if base == "" {
-			base = "HEAD~10"
+			base = defaultSummaryBase()
 		}
 
 		// Obtém a diferença entre a base e o HEAD atual
 		diffCmd := exec.Command("git", "diff", "--name-only", base)
 		changedFilesBytes, err := diffCmd.Output()
 		if err != nil {
-			return fmt.Errorf("erro ao obter lista de arquivos alterados: %w", err)
+			return i18n.Errorf("summary.files_error", err)
 		}
 
 		changedFiles := strings.Split(strings.TrimSpace(string(changedFilesBytes)), "\n")
 		if len(changedFiles) == 0 || (len(changedFiles) == 1 && changedFiles[0] == "") {
-			return fmt.Errorf("nenhuma alteração encontrada desde %s", base)
+			return i18n.Errorf("summary.no_changes", base)
 		}
 
 		// Limita o número de arquivos se necessário
@@ -58,15 +64,15 @@ var summaryCmd = &cobra.Command{
 		// Obtém o diff detalhado para cada arquivo
 		fileChanges := make(map[string]string)
 		for _, file := range changedFiles {
-			// Ignora arquivos binários, imagens, etc.
-			if isIgnorableFile(file) {
+			// Ignora arquivos binários, imagens, etc. e os bloqueados pela política
+			if isIgnorableFile(file) || !policy.Allowed(file) {
 				continue
 			}
 
 			diffCmd := exec.Command("git", "diff", base, "--", file)
 			diffOutput, err := diffCmd.Output()
 			if err != nil {
-				fmt.Printf("Aviso: Erro ao obter diff para %s: %v\n", file, err)
+				output.Progress(i18n.T("summary.diff_warning", file, err))
 				continue
 			}
 
@@ -75,59 +81,79 @@ var summaryCmd = &cobra.Command{
 
 		// Constrói o prompt para a IA
 		prompt := buildSummaryPrompt(fileChanges, includeCode)
-
-		// Carrega configuração
-		config, err := commons.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
-		}
-
-		// Obtém o provedor de IA configurado
-		provider, exists := ai.GetProvider(config.AIProvider)
-		if !exists {
-			provider = ai.GetDefaultProvider()
-		}
+		prompt += i18n.PromptInstruction(i18n.Portuguese)
 
 		// Gera o resumo
-		fmt.Println("Gerando resumo das alterações...")
-		summary, err := provider.GetCompletions(prompt, config.AIModel)
+		output.Progress(i18n.T("summary.generating"))
+		summary, err := ai.Complete(prompt)
 		if err != nil {
-			return fmt.Errorf("erro ao gerar resumo: %w", err)
+			return i18n.Errorf("summary.error", err)
 		}
 
 		// Formata o resumo conforme solicitado
-		formattedSummary := formatSummary(summary, format)
+		formattedSummary := formatSummary(summary.Text, format)
+		result := newResult(cmd, summary)
+		result.Text = formattedSummary
 
 		// Salva o resumo em um arquivo se solicitado
-		if saveReport {
-			if reportFile == "" {
-				reportFile = "alteracoes-resumo.md"
-			}
+		if saveReport && reportFile == "" {
+			reportFile = "alteracoes-resumo.md"
+		}
+		if saveReport && !dryrun.Skip(i18n.T("dryrun.write_file", reportFile)) {
 			if err := os.WriteFile(reportFile, []byte(formattedSummary), 0644); err != nil {
-				return fmt.Errorf("erro ao salvar o resumo: %w", err)
+				return i18n.Errorf("summary.save_error", err)
 			}
-			fmt.Printf("Resumo salvo em %s\n", reportFile)
+			output.Progress(i18n.T("summary.saved", reportFile))
+			result.Files = []string{reportFile}
 		}
 
 		// Exibe o resumo
-		fmt.Println(formattedSummary)
-		return nil
+		output.Print(formattedSummary)
+		return emitResult(result)
 	},
 }
 
+// defaultSummaryBase retorna o merge-base entre HEAD e a branch padrão do repositório, ou HEAD~10
+// quando não há branch padrão ou o HEAD já está nela
+func defaultSummaryBase() string {
+	defaultBranch, err := git.GetDefaultBranch()
+	if err != nil {
+		return "HEAD~10"
+	}
+	baseRef := git.BaseRef(defaultBranch)
+	mergeBase, err := git.GetMergeBase(baseRef, "HEAD")
+	if err != nil {
+		return "HEAD~10"
+	}
+	head, err := exec.Command("git", "rev-parse", "HEAD").Output()
+	if err != nil || strings.TrimSpace(string(head)) == mergeBase {
+		return "HEAD~10"
+	}
+	output.Progress(i18n.T("summary.using_base", mergeBase[:7], baseRef))
+	return mergeBase
+}
+
 // buildSummaryPrompt cria o prompt para a IA gerar o resumo
 func buildSummaryPrompt(fileChanges map[string]string, includeCode bool) string {
 	var sb strings.Builder
-	
+
 	sb.WriteString("Gere um resumo detalhado das seguintes alterações em um repositório Git. ")
 	sb.WriteString("Agrupe as alterações por funcionalidade ou componente, e descreva: ")
 	sb.WriteString("1. As principais funcionalidades adicionadas ou modificadas\n")
 	sb.WriteString("2. Correções de bugs realizadas\n")
 	sb.WriteString("3. Refatorações e melhorias de código\n")
 	sb.WriteString("4. Alterações de dependências ou configurações\n\n")
-	
+
+	// Os arquivos seguem em ordem alfabética para que as mesmas alterações gerem o mesmo prompt (e acertem o cache)
+	files := make([]string, 0, len(fileChanges))
+	for file := range fileChanges {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
 	sb.WriteString("Arquivos alterados:\n")
-	for file, diff := range fileChanges {
+	for _, file := range files {
+		diff := fileChanges[file]
 		sb.WriteString("\n## " + file + "\n")
 		if includeCode {
 			sb.WriteString("```diff\n" + diff + "\n```\n")
@@ -141,10 +167,10 @@ func buildSummaryPrompt(fileChanges map[string]string, includeCode bool) string
 			}
 		}
 	}
-	
+
 	sb.WriteString("\nOrganize o resumo de forma clara e concisa, destacando as alterações mais importantes. ")
 	sb.WriteString("Formate o resultado usando Markdown, com títulos e listas para melhor legibilidade.")
-	
+
 	return sb.String()
 }
 
@@ -195,37 +221,37 @@ func isIgnorableFile(file string) bool {
 		".o", ".so", ".dll", ".exe", ".bin",
 		".log", ".cache",
 	}
-	
+
 	ext := strings.ToLower(filepath.Ext(file))
 	for _, ignorable := range ignorableExts {
 		if ext == ignorable {
 			return true
 		}
 	}
-	
+
 	// Diretórios ou arquivos que não são código
 	ignorablePaths := []string{
 		"node_modules/", ".git/", "dist/", "build/", "vendor/",
 		"package-lock.json", "yarn.lock", ".DS_Store",
 	}
-	
+
 	for _, ignorable := range ignorablePaths {
 		if strings.Contains(file, ignorable) {
 			return true
 		}
 	}
-	
+
 	return false
 }
 
 func init() {
 	RootCmd.AddCommand(summaryCmd)
-	
+
 	// Flags para o comando summary
-	summaryCmd.Flags().StringVarP(&base, "base", "b", "", "Commit ou branch base para comparação (padrão: HEAD~10)")
+	summaryCmd.Flags().StringVarP(&base, "base", "b", "", "Commit ou branch base para comparação (padrão: o ponto de divergência da branch padrão, ou HEAD~10 nela)")
 	summaryCmd.Flags().StringVarP(&format, "format", "f", "markdown", "Formato do relatório (markdown, jira, text, html)")
 	summaryCmd.Flags().BoolVarP(&saveReport, "save", "s", false, "Salvar relatório em um arquivo")
-	summaryCmd.Flags().StringVarP(&reportFile, "output", "o", "", "Arquivo para salvar o relatório (padrão: alteracoes-resumo.md)")
+	summaryCmd.Flags().StringVarP(&reportFile, "output-file", "o", "", "Arquivo para salvar o relatório (padrão: alteracoes-resumo.md)")
 	summaryCmd.Flags().IntVarP(&maxChanges, "max", "m", 20, "Número máximo de arquivos a incluir (0 para todos)")
 	summaryCmd.Flags().BoolVarP(&includeCode, "code", "c", false, "Incluir código detalhado no prompt (aumenta precisão, mas consome mais tokens)")
-}
\ No newline at end of file
+}
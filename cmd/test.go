@@ -1,10 +1,18 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
+	"gojira/utils/redact"
+	"gojira/utils/source"
+	"gojira/utils/testrun"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,6 +24,11 @@ var (
 	testFile      string
 	testFramework string
 	coverage      string
+	testContext   int
+	verifyTests   bool
+	maxAttempts   int
+	uncoveredOnly bool
+	replaceTests  []string
 )
 
 // testCmd representa o comando para gerar testes
@@ -25,12 +38,21 @@ var testCmd = &cobra.Command{
 	Long:  `Analisa o código-fonte e gera testes automaticamente utilizando inteligência artificial, cobrindo funções, classes e métodos.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if sourceFile == "" {
-			return fmt.Errorf("é necessário fornecer o caminho para um arquivo fonte")
+			return errors.New(i18n.T("test.no_source"))
+		}
+
+		if maxAttempts < 1 {
+			return i18n.Errorf("test.invalid_attempts", maxAttempts)
 		}
 
 		// Verifica se o arquivo fonte existe
 		if _, err := os.Stat(sourceFile); os.IsNotExist(err) {
-			return fmt.Errorf("o arquivo fonte %s não existe", sourceFile)
+			return i18n.Errorf("test.source_missing", sourceFile)
+		}
+
+		// Verifica se a política permite enviar o arquivo
+		if !policy.Allowed(sourceFile) {
+			return i18n.Errorf("policy.file_denied", sourceFile)
 		}
 
 		// Determina o arquivo de teste se não foi especificado
@@ -38,7 +60,7 @@ var testCmd = &cobra.Command{
 			dir, fileName := filepath.Split(sourceFile)
 			ext := filepath.Ext(fileName)
 			baseName := fileName[:len(fileName)-len(ext)]
-			
+
 			// Cria nome do arquivo de teste baseado na convenção da linguagem
 			switch ext {
 			case ".go":
@@ -57,7 +79,7 @@ var testCmd = &cobra.Command{
 		// Lê o conteúdo do arquivo fonte
 		sourceContent, err := os.ReadFile(sourceFile)
 		if err != nil {
-			return fmt.Errorf("erro ao ler o arquivo fonte: %w", err)
+			return i18n.Errorf("test.read_error", err)
 		}
 
 		// Determina a linguagem com base na extensão do arquivo
@@ -69,53 +91,366 @@ var testCmd = &cobra.Command{
 			testFramework = inferTestFramework(language)
 		}
 
+		// Com --uncovered, mede a cobertura do pacote para pedir testes só do que falta cobrir
+		var measured *coverageReport
+		if uncoveredOnly {
+			if language != "Go" {
+				return errors.New(i18n.T("test.uncovered_go_only"))
+			}
+			var err error
+			measured, err = measureGaps(sourceFile)
+			if err != nil {
+				return err
+			}
+			if len(measured.Gaps) == 0 {
+				output.Progress(i18n.T("test.fully_covered", sourceFile))
+				result := newResult(cmd, nil)
+				result.Data = measured
+				return emitResult(result)
+			}
+		}
+
 		// Constrói o prompt para a IA
 		prompt := buildTestGenerationPrompt(string(sourceContent), language, testFramework, coverage)
+		if measured != nil {
+			prompt += buildUncoveredPrompt(measured.Gaps)
+		}
+		prompt += buildPackageContextPrompt(sourceFile, testContext)
 
-		// Verifica se o arquivo de teste já existe
+		// Verifica se o arquivo de teste já existe. Em Go, os testes gerados são mesclados ao arquivo
+		// pela AST, mesmo quando a política impede enviá-lo; nas demais linguagens, a IA devolve o
+		// arquivo completo com os testes integrados, o que exige enviar o conteúdo atual. O arquivo
+		// existente nunca é sobrescrito sem que os testes sejam integrados a ele.
 		var existingTests string
+		mergeTests := false
 		if _, err := os.Stat(testFile); err == nil {
 			existingTestsBytes, err := os.ReadFile(testFile)
-			if err == nil {
-				existingTests = string(existingTestsBytes)
+			if err != nil {
+				return i18n.Errorf("test.read_existing_error", testFile, err)
+			}
+			existingTests = string(existingTestsBytes)
+			shared := policy.Allowed(testFile)
+			switch {
+			case language == "Go":
+				mergeTests = true
+				prompt += buildMergePrompt(existingTests, shared, replaceTests)
+			case shared:
 				prompt += fmt.Sprintf("\n\nO arquivo de teste já existe com o seguinte conteúdo. "+
 					"Integre seus novos testes com os existentes, mantendo a cobertura atual e "+
 					"adicionando os novos casos de teste:\n\n```\n%s\n```", existingTests)
+			default:
+				return i18n.Errorf("test.existing_denied", testFile)
 			}
 		}
 
-		// Carrega configuração
-		config, err := commons.LoadConfig()
+		// Gera os testes
+		output.Progress(i18n.T("test.generating"))
+		request := ai.NewRequest(prompt)
+		var response testGenerationResponse
+		generation, err := ai.CompleteJSONRequest(request, &response)
 		if err != nil {
-			return fmt.Errorf("erro ao carregar configuração: %w", err)
+			return i18n.Errorf("test.error", err)
 		}
 
-		// Obtém o provedor de IA configurado
-		provider, exists := ai.GetProvider(config.AIProvider)
-		if !exists {
-			provider = ai.GetDefaultProvider()
+		// A resposta estruturada traz apenas o código, sem explicações ou markdown
+		testCode := response.Code
+		if generation.DryRun {
+			testCode = generation.Text
 		}
 
-		// Gera os testes
-		fmt.Println("Gerando testes...")
-		testCode, err := provider.GetCompletions(prompt, config.AIModel)
-		if err != nil {
-			return fmt.Errorf("erro ao gerar testes: %w", err)
+		result := newResult(cmd, generation)
+		result.Text = testCode
+		if measured != nil {
+			result.Data = measured
 		}
 
-		// Extrai apenas o código de teste (remove explicações e markdown)
-		testCode = extractCodeFromMarkdown(testCode, language)
+		// Cada versão gerada passa por prepare antes de ser gravada
+		var merge *source.Merge
+		prepare := func(code string) (string, error) {
+			return code, nil
+		}
+		if mergeTests {
+			prepare = func(code string) (string, error) {
+				merged, err := mergeGeneratedTests(existingTests, code)
+				if err != nil {
+					return "", err
+				}
+				merge = merged
+				return merged.Code, nil
+			}
+		}
 
-		// Salva os testes no arquivo
-		if err := os.WriteFile(testFile, []byte(testCode), 0644); err != nil {
-			return fmt.Errorf("erro ao salvar os testes: %w", err)
+		// Os trechos mascarados no envio já voltaram à resposta; um marcador que o provedor alterou
+		// não pode ser restaurado, e o arquivo não é gravado com ele
+		build := prepare
+		prepare = func(code string) (string, error) {
+			if redact.Marked(code) {
+				return "", i18n.Errorf("test.redacted_output", testFile)
+			}
+			return build(code)
+		}
+
+		// Salva os testes no arquivo, executando-os e pedindo correções quando a linguagem é suportada
+		if !dryrun.Skip(i18n.T("dryrun.write_file", testFile)) {
+			// Sem a ferramenta de testes instalada, os testes são gravados sem verificação
+			runner, supported := testrun.For(language)
+			if verifyTests && supported {
+				if err := runner.Available(testFile); err != nil {
+					output.Progress(i18n.T("test.verify_unavailable", err))
+					supported = false
+				} else {
+					output.Progress(i18n.T("test.verify_warning", runner.Name()))
+				}
+			}
+			if verifyTests && supported {
+				report, err := verifyGeneratedTests(runner, request, generation.Text, testCode, mergeTests, prepare)
+				if err != nil {
+					return err
+				}
+				printTestReport(report)
+				report.Coverage = measured
+				report.Merge = merge
+				result.Text = report.code
+				result.Data = report
+				if report.Restored {
+					return emitResult(result)
+				}
+			} else {
+				code, err := prepare(testCode)
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(testFile, []byte(code), 0644); err != nil {
+					return i18n.Errorf("test.save_error", err)
+				}
+				result.Text = code
+				if measured == nil && merge != nil {
+					result.Data = merge
+				}
+			}
+			output.Progress(i18n.T("test.saved", testFile))
+			result.Files = []string{testFile}
+
+			// Mede a cobertura novamente para mostrar o ganho
+			if measured != nil {
+				after, err := testrun.GoCoverage(filepath.Dir(sourceFile))
+				if err != nil {
+					output.Progress(i18n.T("test.coverage_after_error", err))
+				} else {
+					measured.After = &after.Percent
+					output.Progress(i18n.T("test.coverage_after", measured.Before, after.Percent))
+				}
+			}
 		}
 
-		fmt.Printf("Testes gerados com sucesso e salvos em %s\n", testFile)
-		return nil
+		return emitResult(result)
 	},
 }
 
+// testGenerationResponse representa a resposta estruturada da geração de testes
+type testGenerationResponse struct {
+	Code string `json:"code" description:"Complete content of the test file, ready to be saved, without markdown fences"`
+}
+
+// testReport representa o resultado da execução e correção dos testes gerados
+type testReport struct {
+	Runner   string          `json:"runner"`
+	Attempts int             `json:"attempts"`
+	Passed   bool            `json:"passed"`
+	Passing  []string        `json:"passing"`
+	Failing  []string        `json:"failing"`
+	Removed  []string        `json:"removed"`
+	Restored bool            `json:"restored"`
+	Coverage *coverageReport `json:"coverage,omitempty"`
+	Merge    *source.Merge   `json:"merge,omitempty"`
+	code     string
+}
+
+// coverageReport representa a cobertura do pacote antes e depois da geração com --uncovered
+type coverageReport struct {
+	Before float64       `json:"before"`
+	After  *float64      `json:"after,omitempty"`
+	Gaps   []testrun.Gap `json:"gaps"`
+}
+
+// measureGaps mede a cobertura do pacote do arquivo e encontra as funções sem cobertura total
+func measureGaps(file string) (*coverageReport, error) {
+	output.Progress(i18n.T("test.measuring_coverage"))
+	before, err := testrun.GoCoverage(filepath.Dir(file))
+	if err != nil {
+		return nil, i18n.Errorf("test.coverage_error", err)
+	}
+
+	gaps, err := before.Gaps(file)
+	if err != nil {
+		return nil, i18n.Errorf("test.coverage_error", err)
+	}
+
+	output.Progress(i18n.T("test.coverage_before", before.Percent, len(gaps)))
+	return &coverageReport{Before: before.Percent, Gaps: gaps}, nil
+}
+
+// buildUncoveredPrompt restringe a geração às funções e linhas sem cobertura
+func buildUncoveredPrompt(gaps []testrun.Gap) string {
+	var builder strings.Builder
+	builder.WriteString("\n\nOs testes existentes já cobrem parte do código. Gere testes APENAS para as funções " +
+		"e os trechos abaixo, que nenhum teste executa hoje, sem repetir casos já cobertos:\n")
+	for _, gap := range gaps {
+		if gap.Uncovered {
+			builder.WriteString(fmt.Sprintf("- %s: nenhuma linha coberta\n", gap.Function))
+		} else {
+			builder.WriteString(fmt.Sprintf("- %s: linhas %s sem cobertura\n", gap.Function, strings.Join(gap.Lines, ", ")))
+		}
+	}
+	return builder.String()
+}
+
+// buildMergePrompt pede apenas os testes novos, que serão mesclados ao arquivo Go existente. Quando a
+// política impede enviar o arquivo, só o nome do pacote é informado.
+func buildMergePrompt(existingTests string, shared bool, replace []string) string {
+	prompt := "\n\nO arquivo de teste já existe, mas seu conteúdo não pode ser enviado. "
+	if shared {
+		prompt = fmt.Sprintf("\n\nO arquivo de teste já existe com o seguinte conteúdo:\n\n```go\n%s\n```\n\n", existingTests)
+	}
+	prompt += "Responda com um arquivo Go completo (package e imports) contendo APENAS os novos testes e funções auxiliares, " +
+		"sem repetir as funções existentes: eles serão mesclados automaticamente ao arquivo, e funções com nomes " +
+		"já existentes serão descartadas."
+	if name := source.GoPackageName(existingTests); name != "" {
+		prompt += fmt.Sprintf(" Use o mesmo pacote do arquivo existente: package %s.", name)
+	}
+	if len(replace) > 0 {
+		prompt += fmt.Sprintf(" As funções %s podem ser reescritas: para isso, use exatamente o mesmo nome.",
+			strings.Join(replace, ", "))
+	}
+	return prompt
+}
+
+// mergeGeneratedTests mescla os testes gerados ao arquivo Go existente, exibindo as colisões e o diff
+// das alterações antes que o arquivo seja gravado
+func mergeGeneratedTests(existingTests, generated string) (*source.Merge, error) {
+	merge, err := source.MergeGoTests(existingTests, generated, source.GoModule(filepath.Dir(testFile)), replaceTests)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(merge.Collisions) > 0 {
+		output.Progress(i18n.T("test.merge_collisions", strings.Join(merge.Collisions, ", ")))
+	}
+	if len(merge.Added) == 0 && len(merge.Replaced) == 0 {
+		output.Progress(i18n.T("test.merge_unchanged", testFile))
+		return merge, nil
+	}
+
+	output.Progress(i18n.T("test.merge_summary", len(merge.Added), len(merge.Replaced)))
+	diff, err := git.DiffText(testFile, existingTests, merge.Code)
+	if err != nil {
+		return nil, err
+	}
+	output.Progress(i18n.T("test.merge_diff", testFile))
+	output.Progress(strings.TrimRight(diff, "\n"))
+	return merge, nil
+}
+
+// maxFailureOutput limita quantos caracteres da saída dos testes voltam para a IA
+const maxFailureOutput = 6000
+
+// verifyGeneratedTests grava e executa os testes, devolvendo as falhas à IA até que passem ou
+// as tentativas acabem. Se nenhuma versão passar, o arquivo de teste original é restaurado.
+// Com merging, as correções continuam trazendo apenas os testes novos, mesclados ao arquivo original.
+func verifyGeneratedTests(runner testrun.Runner, request *ai.Request, answer, generated string, merging bool, prepare func(string) (string, error)) (*testReport, error) {
+	original, readErr := os.ReadFile(testFile)
+	restore := func() error {
+		if readErr == nil {
+			return os.WriteFile(testFile, original, 0644)
+		}
+		return os.Remove(testFile)
+	}
+
+	report := &testReport{Runner: runner.Name(), Removed: []string{}}
+	firstCode := ""
+	for attempt := 1; ; attempt++ {
+		testCode, err := prepare(generated)
+		if err != nil {
+			_ = restore()
+			return nil, err
+		}
+		if attempt == 1 {
+			firstCode = testCode
+		}
+		if err := os.WriteFile(testFile, []byte(testCode), 0644); err != nil {
+			return nil, i18n.Errorf("test.save_error", err)
+		}
+
+		output.Progress(i18n.T("test.running", runner.Name(), attempt, maxAttempts))
+		run, err := runner.Run(testFile)
+		if err != nil {
+			_ = restore()
+			return nil, err
+		}
+
+		report.Attempts = attempt
+		report.Passing = run.Passing
+		report.Failing = run.Failing
+		report.code = testCode
+		if run.Passed {
+			report.Passed = true
+			report.Removed = testrun.Removed(runner, firstCode, testCode)
+			return report, nil
+		}
+
+		if attempt >= maxAttempts {
+			if err := restore(); err != nil {
+				return nil, i18n.Errorf("test.restore_error", err)
+			}
+			report.Restored = true
+			return report, nil
+		}
+
+		// Continua a conversa com a saída da execução para que a IA corrija os testes
+		failure := run.Output
+		if len(failure) > maxFailureOutput {
+			failure = failure[len(failure)-maxFailureOutput:]
+		}
+		output.Progress(i18n.T("test.fixing", len(run.Failing)))
+		answerFormat := "Responda com o arquivo de teste completo."
+		if merging {
+			answerFormat = "Responda, como antes, com um arquivo Go contendo APENAS os testes novos e as funções auxiliares, " +
+				"sem as funções que já existiam no arquivo: eles serão mesclados novamente ao arquivo original."
+		}
+		request = request.Reply(answer, fmt.Sprintf(
+			"Os testes gerados não passaram. Saída de %s:\n\n```\n%s\n```\n\n"+
+				"Corrija o arquivo de teste para que compile e passe. Não altere o código-fonte; se um caso de teste "+
+				"depender de um comportamento que o código não tem, remova esse caso. %s",
+			runner.Name(), failure, answerFormat,
+		))
+
+		var response testGenerationResponse
+		completion, err := ai.CompleteJSONRequest(request, &response)
+		if err != nil {
+			_ = restore()
+			return nil, i18n.Errorf("test.error", err)
+		}
+		answer = completion.Text
+		generated = response.Code
+	}
+}
+
+// printTestReport exibe os casos que passaram, falharam e foram removidos
+func printTestReport(report *testReport) {
+	if report.Passed {
+		output.Progress(i18n.T("test.report_passed", report.Attempts))
+	} else {
+		output.Progress(i18n.T("test.report_restored", report.Attempts, testFile))
+	}
+	output.Progress(i18n.T("test.report_summary", len(report.Passing), len(report.Failing), len(report.Removed)))
+	for _, name := range report.Failing {
+		output.Progress("  ✗ " + name)
+	}
+	for _, name := range report.Removed {
+		output.Progress("  - " + name)
+	}
+}
+
 // buildTestGenerationPrompt cria o prompt para a IA gerar os testes
 func buildTestGenerationPrompt(sourceCode, language, framework, coverage string) string {
 	return fmt.Sprintf(
@@ -200,66 +535,20 @@ func inferTestFramework(language string) string {
 	}
 }
 
-// extractCodeFromMarkdown extrai o código de teste de uma resposta markdown
-func extractCodeFromMarkdown(markdown, language string) string {
-	// Se a resposta já parece ser só código (sem markdown), retorna ela mesma
-	if !strings.Contains(markdown, "```") {
-		return markdown
-	}
-
-	// Identifica blocos de código no markdown
-	var codeBlocks []string
-	lines := strings.Split(markdown, "\n")
-	inCodeBlock := false
-	currentBlock := []string{}
-	languageTag := strings.ToLower(language)
-
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-
-		// Início de um bloco de código
-		if strings.HasPrefix(trimmedLine, "```") {
-			if inCodeBlock {
-				// Fim de um bloco
-				inCodeBlock = false
-				codeBlocks = append(codeBlocks, strings.Join(currentBlock, "\n"))
-				currentBlock = []string{}
-			} else {
-				// Início de um bloco
-				inCodeBlock = true
-				// Ignora a linha de abertura
-			}
-		} else if inCodeBlock {
-			// Linha dentro do bloco de código
-			currentBlock = append(currentBlock, line)
-		}
-	}
-
-	// Se não encontrou blocos de código, retorna o texto original
-	if len(codeBlocks) == 0 {
-		return markdown
-	}
-
-	// Retorna o bloco de código maior (provavelmente é o código de teste completo)
-	largestBlock := ""
-	for _, block := range codeBlocks {
-		if len(block) > len(largestBlock) {
-			largestBlock = block
-		}
-	}
-
-	return largestBlock
-}
-
 func init() {
 	RootCmd.AddCommand(testCmd)
 
 	// Flags para o comando test
 	testCmd.Flags().StringVarP(&sourceFile, "source", "s", "", "Arquivo fonte para o qual gerar testes")
-	testCmd.Flags().StringVarP(&testFile, "output", "o", "", "Arquivo de saída para os testes (opcional)")
+	testCmd.Flags().StringVarP(&testFile, "output-file", "o", "", "Arquivo de saída para os testes (opcional)")
 	testCmd.Flags().StringVarP(&testFramework, "framework", "f", "", "Framework de testes a ser usado (opcional)")
 	testCmd.Flags().StringVarP(&coverage, "coverage", "c", "alta", "Nível de cobertura desejado (básica, média, alta)")
+	testCmd.Flags().BoolVar(&verifyTests, "verify", false, "Executa os testes gerados e pede correções à IA até que passem (Go, pytest e Jest)")
+	testCmd.Flags().IntVar(&maxAttempts, "max-attempts", 3, "Número máximo de execuções dos testes gerados")
+	testCmd.Flags().BoolVar(&uncoveredOnly, "uncovered", false, "Gera testes apenas para as funções e trechos sem cobertura (Go)")
+	testCmd.Flags().StringSliceVar(&replaceTests, "replace", nil, "Funções de teste existentes que os testes gerados podem substituir (Go)")
+	testCmd.Flags().IntVar(&testContext, "context-tokens", source.DefaultContextTokens, "Orçamento de tokens para o contexto do pacote em arquivos Go (0 para desativar)")
 
 	// Marca o parâmetro de arquivo fonte como obrigatório
 	_ = testCmd.MarkFlagRequired("source")
-}
\ No newline at end of file
+}
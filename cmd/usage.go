@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"gojira/services/usage"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"time"
+)
+
+var (
+	// Flags para o comando de uso
+	usageBy    string
+	usageMonth string
+)
+
+// usageCmd representa o comando para consultar o consumo de tokens e o custo estimado
+var usageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Mostra o consumo de tokens e o custo estimado",
+	Long:  `Mostra o consumo de tokens e o custo estimado das chamadas aos provedores de IA no mês, agrupado por dia, comando ou modelo, junto com a situação do orçamento mensal.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if usageBy != usage.ByDay && usageBy != usage.ByCommand && usageBy != usage.ByModel {
+			return i18n.Errorf("usage.invalid_group", usageBy)
+		}
+
+		month := time.Now()
+		if usageMonth != "" {
+			parsed, err := time.ParseInLocation("2006-01", usageMonth, time.Local)
+			if err != nil {
+				return i18n.Errorf("usage.invalid_month", usageMonth)
+			}
+			month = parsed
+		}
+
+		records, err := usage.List(month)
+		if err != nil {
+			return err
+		}
+		summaries := usage.Group(records, usageBy)
+
+		total := &usage.Summary{Key: month.Format("2006-01")}
+		for _, summary := range summaries {
+			total.Calls += summary.Calls
+			total.InputTokens += summary.InputTokens
+			total.OutputTokens += summary.OutputTokens
+			total.CostUSD += summary.CostUSD
+		}
+
+		config, err := commons.LoadConfig()
+		if err != nil {
+			return i18n.Errorf("config.load_error", err)
+		}
+
+		if output.IsJSON() {
+			result := newResult(cmd, nil)
+			result.Data = map[string]interface{}{
+				"month":      total.Key,
+				"group_by":   usageBy,
+				"groups":     summaries,
+				"total":      total,
+				"budget_usd": config.MonthlyBudgetUSD,
+			}
+			return emitResult(result)
+		}
+
+		if len(summaries) == 0 {
+			fmt.Println(i18n.T("usage.empty", total.Key))
+			return nil
+		}
+
+		fmt.Println(i18n.T("usage.header", total.Key))
+		fmt.Printf("%-40s %8s %12s %12s %10s\n", i18n.T("usage.column_"+usageBy), i18n.T("usage.column_calls"),
+			i18n.T("usage.column_input"), i18n.T("usage.column_output"), i18n.T("usage.column_cost"))
+		for _, summary := range append(summaries, total) {
+			key := summary.Key
+			if summary == total {
+				key = i18n.T("usage.total")
+			}
+			fmt.Printf("%-40s %8d %12d %12d %10.4f\n", key, summary.Calls, summary.InputTokens, summary.OutputTokens, summary.CostUSD)
+		}
+
+		if config.MonthlyBudgetUSD > 0 {
+			fmt.Println(i18n.T("usage.budget_status", total.CostUSD, config.MonthlyBudgetUSD, total.CostUSD/config.MonthlyBudgetUSD*100))
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(usageCmd)
+
+	usageCmd.Flags().StringVarP(&usageBy, "by", "b", usage.ByDay, "Agrupar por day, command ou model")
+	usageCmd.Flags().StringVarP(&usageMonth, "month", "m", "", "Mês a consultar no formato AAAA-MM (padrão: o atual)")
+}
@@ -0,0 +1,138 @@
+package functions
+
+import (
+	"fmt"
+	"gojira/utils/conventional"
+	"regexp"
+	"strings"
+)
+
+// changelogHeader é o cabeçalho de um CHANGELOG.md novo, no formato Keep a Changelog
+const changelogHeader = "# Changelog\n\n" +
+	"All notable changes to this project will be documented in this file.\n\n" +
+	"The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),\n" +
+	"and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).\n"
+
+// changelogSections associa os tipos de commit às seções do Keep a Changelog, na ordem em que aparecem.
+// Os demais tipos (chore, docs, test, style etc.) só entram quando quebram compatibilidade.
+var changelogSections = []struct {
+	heading string
+	types   []string
+}{
+	{"Added", []string{"feat"}},
+	{"Changed", []string{"refactor", "perf"}},
+	{"Deprecated", []string{"deprecate"}},
+	{"Removed", []string{"remove", "revert"}},
+	{"Fixed", []string{"fix"}},
+	{"Security", []string{"security"}},
+}
+
+// versionHeading reconhece o título de uma versão no changelog, como "## [1.2.0] - 2024-01-31"
+var versionHeading = regexp.MustCompile(`^##\s+\[([^\]]+)\]`)
+
+// ChangelogEntry monta a seção de uma versão no formato Keep a Changelog a partir dos commits
+func ChangelogEntry(version, date string, commits []*conventional.Commit) string {
+	grouped := map[string][]string{}
+	for _, commit := range commits {
+		heading := changelogHeading(commit)
+		if heading == "" {
+			continue
+		}
+		line := commit.Subject
+		if commit.Scope != "" {
+			line = fmt.Sprintf("**%s:** %s", commit.Scope, line)
+		}
+		if commit.Breaking {
+			line = "**BREAKING:** " + line
+			if note := commit.BreakingNote(); note != commit.Subject {
+				line += " — " + note
+			}
+		}
+		if commit.Ticket != "" {
+			line += fmt.Sprintf(" (%s)", commit.Ticket)
+		}
+		grouped[heading] = append(grouped[heading], "- "+line)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## [%s] - %s\n", strings.TrimPrefix(version, "v"), date))
+	for _, section := range changelogSections {
+		if items := grouped[section.heading]; len(items) > 0 {
+			sb.WriteString("\n### " + section.heading + "\n\n" + strings.Join(items, "\n") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// changelogHeading retorna a seção do changelog do commit, ou vazio se ele não entrar no changelog
+func changelogHeading(commit *conventional.Commit) string {
+	for _, section := range changelogSections {
+		for _, commitType := range section.types {
+			if commit.Type == commitType {
+				return section.heading
+			}
+		}
+	}
+	if commit.Breaking {
+		return "Changed"
+	}
+	return ""
+}
+
+// UpdateChangelog insere a seção da versão no changelog, logo após [Unreleased] ou antes da versão
+// mais recente. Se a versão já existir, mesmo que não seja a mais recente, a seção dela é substituída
+// no lugar; sem changelog, um novo é criado.
+func UpdateChangelog(existing, version, entry string) string {
+	if strings.TrimSpace(existing) == "" {
+		return changelogHeader + "\n" + entry
+	}
+
+	version = strings.TrimPrefix(version, "v")
+	lines := strings.Split(strings.TrimRight(existing, "\n"), "\n")
+	insert, replaceEnd := len(lines), -1
+	for i, line := range lines {
+		match := versionHeading.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		name := strings.TrimPrefix(match[1], "v")
+		if strings.EqualFold(name, "unreleased") {
+			continue
+		}
+		if name == version {
+			insert, replaceEnd = i, nextVersion(lines, i+1)
+			break
+		}
+		if insert == len(lines) {
+			insert = i
+		}
+	}
+
+	// As referências de links ([1.0.0]: https://...) no final do arquivo continuam depois das versões
+	if insert == len(lines) {
+		for insert > 0 && (strings.HasPrefix(lines[insert-1], "[") && strings.Contains(lines[insert-1], "]: ") || strings.TrimSpace(lines[insert-1]) == "") {
+			insert--
+		}
+	}
+
+	after := lines[insert:]
+	if replaceEnd >= 0 {
+		after = lines[replaceEnd:]
+	}
+	before := strings.TrimRight(strings.Join(lines[:insert], "\n"), "\n")
+	result := before + "\n\n" + strings.TrimRight(entry, "\n") + "\n"
+	if rest := strings.Join(after, "\n"); strings.TrimSpace(rest) != "" {
+		result += "\n" + strings.TrimLeft(rest, "\n") + "\n"
+	}
+	return result
+}
+
+// nextVersion retorna a posição do próximo título de versão a partir de start, ou o fim das seções
+func nextVersion(lines []string, start int) int {
+	for i := start; i < len(lines); i++ {
+		if versionHeading.MatchString(lines[i]) || strings.HasPrefix(lines[i], "[") && strings.Contains(lines[i], "]: ") {
+			return i
+		}
+	}
+	return len(lines)
+}
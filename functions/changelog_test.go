@@ -0,0 +1,57 @@
+package functions
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUpdateChangelog(t *testing.T) {
+	existing := "# Changelog\n\n## [Unreleased]\n\n## [1.2.0] - 2024-03-01\n\n### Added\n\n- b\n\n" +
+		"## [1.1.0] - 2024-02-01\n\n### Fixed\n\n- a\n\n[1.2.0]: https://example.com/1.2.0\n"
+
+	tests := []struct {
+		name    string
+		version string
+		entry   string
+		want    string
+	}{
+		{
+			name:    "nova versão entra antes da mais recente",
+			version: "v1.3.0",
+			entry:   "## [1.3.0] - 2024-04-01\n\n### Added\n\n- c\n",
+			want: "# Changelog\n\n## [Unreleased]\n\n## [1.3.0] - 2024-04-01\n\n### Added\n\n- c\n\n## [1.2.0] - 2024-03-01\n\n### Added\n\n- b\n\n" +
+				"## [1.1.0] - 2024-02-01\n\n### Fixed\n\n- a\n\n[1.2.0]: https://example.com/1.2.0\n",
+		},
+		{
+			name:    "versão mais recente é substituída",
+			version: "1.2.0",
+			entry:   "## [1.2.0] - 2024-03-02\n\n### Added\n\n- b2\n",
+			want: "# Changelog\n\n## [Unreleased]\n\n## [1.2.0] - 2024-03-02\n\n### Added\n\n- b2\n\n" +
+				"## [1.1.0] - 2024-02-01\n\n### Fixed\n\n- a\n\n[1.2.0]: https://example.com/1.2.0\n",
+		},
+		{
+			name:    "versão antiga é substituída no lugar",
+			version: "1.1.0",
+			entry:   "## [1.1.0] - 2024-02-02\n\n### Fixed\n\n- a2\n",
+			want: "# Changelog\n\n## [Unreleased]\n\n## [1.2.0] - 2024-03-01\n\n### Added\n\n- b\n\n" +
+				"## [1.1.0] - 2024-02-02\n\n### Fixed\n\n- a2\n\n[1.2.0]: https://example.com/1.2.0\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := UpdateChangelog(existing, test.version, test.entry)
+			if got != test.want {
+				t.Errorf("UpdateChangelog:\n%s\nesperado:\n%s", got, test.want)
+			}
+			version := strings.TrimPrefix(test.version, "v")
+			if count := strings.Count(got, "## ["+version+"]"); count != 1 {
+				t.Errorf("%d seções da versão %s", count, version)
+			}
+		})
+	}
+
+	if got := UpdateChangelog("", "1.0.0", "## [1.0.0] - 2024-01-01\n"); !strings.HasPrefix(got, "# Changelog") || !strings.HasSuffix(got, "## [1.0.0] - 2024-01-01\n") {
+		t.Errorf("changelog novo:\n%s", got)
+	}
+}
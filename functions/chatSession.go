@@ -0,0 +1,182 @@
+package functions
+
+import (
+	"encoding/json"
+	"fmt"
+	"gojira/services"
+	"gojira/services/ai"
+	"gojira/utils/commons"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/policy"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ChatSession representa uma conversa com a IA sobre o repositório atual.
+// O contexto anexado por /file, /diff, /issue e /branch acompanha a próxima pergunta.
+type ChatSession struct {
+	Request   *ai.Request `json:"request"`
+	CreatedAt time.Time   `json:"created_at"`
+	pending   []string
+}
+
+// NewChatSession inicia uma conversa com o prompt de sistema que apresenta o repositório
+func NewChatSession() *ChatSession {
+	system := fmt.Sprintf(
+		"Você é um assistente de engenharia de software conversando com um desenvolvedor sobre o projeto %s. "+
+			"O desenvolvedor pode anexar arquivos, diffs, tarefas do Jira e o estado da branch às mensagens. "+
+			"Responda com base nesse contexto, de forma objetiva, usando Markdown e blocos de código quando apropriado. "+
+			"Se faltar contexto para responder, diga qual arquivo ou informação seria necessário.",
+		getProjectName(),
+	)
+	system += i18n.PromptInstruction(i18n.Portuguese)
+
+	return &ChatSession{
+		Request:   &ai.Request{System: system},
+		CreatedAt: time.Now(),
+	}
+}
+
+// AttachFile anexa o conteúdo de um arquivo do projeto à próxima pergunta
+func (s *ChatSession) AttachFile(path string) error {
+	content, err := ReadProjectFile(path)
+	if err != nil {
+		return err
+	}
+
+	s.pending = append(s.pending, fmt.Sprintf("Arquivo %s:\n```%s\n%s\n```", path,
+		strings.TrimPrefix(filepath.Ext(path), "."), content))
+	return nil
+}
+
+// AttachDiff anexa as alterações em stage à próxima pergunta
+func (s *ChatSession) AttachDiff() error {
+	diffs, err := git.GetGitDiff()
+	if err != nil {
+		return err
+	}
+
+	files := make([]string, 0, len(diffs))
+	for file := range diffs {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var builder strings.Builder
+	builder.WriteString("Alterações em stage:\n")
+	for _, file := range files {
+		builder.WriteString(fmt.Sprintf("```diff\n%s\n```\n", diffs[file]))
+	}
+	s.pending = append(s.pending, builder.String())
+	return nil
+}
+
+// AttachIssue anexa o resumo e a descrição de uma tarefa do Jira à próxima pergunta
+func (s *ChatSession) AttachIssue(key string) error {
+	issue, err := services.GetJiraIssue(key)
+	if err != nil {
+		return err
+	}
+
+	s.pending = append(s.pending, fmt.Sprintf("Tarefa %s (%s): %s\n%s",
+		issue.Key, issue.Type, issue.Summary, issue.Description))
+	return nil
+}
+
+// AttachBranch anexa a branch atual, seus commits recentes e os arquivos alterados à próxima pergunta
+func (s *ChatSession) AttachBranch() error {
+	branch, err := git.GetBranchName()
+	if err != nil {
+		return err
+	}
+
+	commits, err := exec.Command("git", "log", "--oneline", "--no-merges", "-n", "20").Output()
+	if err != nil {
+		return i18n.Errorf("chat.branch_error", err)
+	}
+	status, err := exec.Command("git", "status", "--short").Output()
+	if err != nil {
+		return i18n.Errorf("chat.branch_error", err)
+	}
+
+	s.pending = append(s.pending, fmt.Sprintf("Branch atual: %s\n\nCommits recentes:\n%s\nArquivos alterados:\n%s",
+		branch, string(commits), string(status)))
+	return nil
+}
+
+// Pending retorna quantos anexos aguardam a próxima pergunta
+func (s *ChatSession) Pending() int {
+	return len(s.pending)
+}
+
+// Ask envia a pergunta, com os anexos pendentes, e guarda a resposta na conversa
+func (s *ChatSession) Ask(question string) (*ai.Completion, error) {
+	content := question
+	if len(s.pending) > 0 {
+		content = strings.Join(s.pending, "\n\n") + "\n\n" + question
+	}
+
+	request := *s.Request
+	request.Messages = append(append([]ai.Message{}, s.Request.Messages...),
+		ai.Message{Role: ai.RoleUser, Content: content})
+
+	completion, err := ai.CompleteRequest(&request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Em --dry-run não há resposta real, então a conversa não avança
+	if !completion.DryRun {
+		request.Messages = append(request.Messages, ai.Message{Role: ai.RoleAssistant, Content: completion.Text})
+		s.Request = &request
+		s.pending = nil
+	}
+	return completion, nil
+}
+
+// GetChatDir retorna o diretório onde as conversas são salvas
+func GetChatDir() string {
+	return filepath.Join(commons.GetDataDir(), "chats")
+}
+
+// Save grava a conversa em ~/.gojira/chats e retorna o caminho do arquivo
+func (s *ChatSession) Save() (string, error) {
+	dir := GetChatDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", i18n.Errorf("chat.save_error", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", i18n.Errorf("chat.save_error", err)
+	}
+
+	path := filepath.Join(dir, s.CreatedAt.Format("20060102-150405")+".json")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return "", i18n.Errorf("chat.save_error", err)
+	}
+	return path, nil
+}
+
+// ReadProjectFile lê um arquivo do projeto para enviá-lo à IA, respeitando a política de
+// arquivos e ignorando arquivos binários ou grandes demais
+func ReadProjectFile(path string) (string, error) {
+	if !policy.Allowed(path) {
+		return "", i18n.Errorf("policy.file_denied", path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", i18n.Errorf("chat.file_read_error", path, err)
+	}
+
+	if isBinary(content) || len(content) > maxProjectFileSize {
+		return "", i18n.Errorf("chat.file_unsupported", path)
+	}
+	return string(content), nil
+}
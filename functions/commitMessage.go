@@ -3,22 +3,24 @@ package functions
 import (
 	"fmt"
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/conventional"
 	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"strings"
 )
 
 //goland:noinspection GoPrintFunctions
-func GenerateCommitMessage(diffs map[string]string, branch string) (string, error) {
+func GenerateCommitMessage(diffs map[string]string, branch string) (*ai.Completion, error) {
 	commitType, context, err := git.ParseBranchForCommitType(branch)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	prompt := fmt.Sprintf(
 		"You are an AI assistant trained to generate commit messages following the Conventional Commits standard. "+
 			"Analyze the Git diffs below and, based on the current branch context and Git Flow rules, generate a commit message "+
 			"with the following format, **without introductions or explanations**:\n\n"+
-			"  %s: [%s] Concise commit message in US English\n\n"+
+			"  %s: [%s] Concise commit message in %s\n\n"+
 			"- Item 1: Brief and clear description of what was changed or added.\n"+
 			"- Item 2: Another brief description of an improvement or fix.\n"+
 			"- ... (add more items if necessary).\n\n"+
@@ -45,23 +47,48 @@ func GenerateCommitMessage(diffs map[string]string, branch string) (string, erro
 			"- Resolved inconsistency in monthly report generation.\n"+
 			"- Added unit tests for edge cases.\n\n"+
 			"Respond **exactly** in the format above, without additional explanations.",
-		commitType, context)
+		commitType, context, i18n.LanguageName(i18n.ContentLanguage(i18n.English)))
 
-	for file, diff := range diffs {
-		prompt += fmt.Sprintf("\n\nBranch: %s\nFile: %s\nChanges:\n%s\n", branch, file, diff)
+	for _, file := range sortedPaths(diffs) {
+		prompt += fmt.Sprintf("\n\nBranch: %s\nFile: %s\nChanges:\n%s\n", branch, file, diffs[file])
 	}
 
-	// Carrega configuração
-	config, err := commons.LoadConfig()
+	return ai.Complete(prompt)
+}
+
+// commitFixResponse representa a resposta estruturada da correção de uma mensagem de commit
+type commitFixResponse struct {
+	Message string `json:"message" description:"The full rewritten commit message: header, blank line and bullet items"`
+}
+
+// FixCommitMessage reescreve uma mensagem fora do padrão "type: [TICKET] Message", a partir da
+// mensagem original, dos problemas encontrados e dos arquivos alterados no commit. O ticket sugerido
+// costuma vir da branch, e maxLength é o tamanho máximo da primeira linha.
+func FixCommitMessage(message string, problems []string, stat, ticket string, maxLength int) (*ai.Completion, error) {
+	prompt := fmt.Sprintf(
+		"Rewrite the Git commit message below so it follows the project's Conventional Commits format, "+
+			"keeping its meaning:\n\n"+
+			"  type: [TICKET] Concise commit message in %s\n\n"+
+			"- Item 1: Brief and clear description of what was changed or added.\n"+
+			"- Item 2: Another brief description of an improvement or fix.\n\n"+
+			"Mandatory rules:\n"+
+			"- `type` must be one of: %s.\n"+
+			"- `TICKET` matches `[A-Z]{2,}-\\d+` and goes in brackets; use %q if the message has no ticket, or leave the brackets out if it is empty.\n"+
+			"- The first line must have at most %d characters, followed by a blank line and the items, each starting with \"- \".\n"+
+			"- Keep trailers such as BREAKING CHANGE: at the end.\n\n"+
+			"Problems found:\n- %s\n\n"+
+			"Files changed:\n%s\n\n"+
+			"Original message:\n%s",
+		i18n.LanguageName(i18n.ContentLanguage(i18n.English)), strings.Join(conventional.Types, ", "), ticket,
+		maxLength, strings.Join(problems, "\n- "), stat, message)
+
+	var response commitFixResponse
+	completion, err := ai.CompleteJSON(prompt, &response)
 	if err != nil {
-		return "", fmt.Errorf("erro ao carregar configuração: %w", err)
+		return nil, err
 	}
-
-	// Obtém o provedor de IA configurado
-	provider, exists := ai.GetProvider(config.AIProvider)
-	if !exists {
-		provider = ai.GetDefaultProvider()
+	if !completion.DryRun {
+		completion.Text = strings.TrimSpace(response.Message)
 	}
-
-	return provider.GetCompletions(prompt, config.AIModel)
+	return completion, nil
 }
@@ -4,12 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
 	"io/fs"
 	"log"
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -19,48 +23,39 @@ import (
 // De forma bem grosseira, 1 token ~ 4 caracteres, mas deixe margem.
 const chunkSize = 10000
 
-func GenerateAnalysis() error {
+// maxProjectFileSize é o tamanho máximo de um arquivo do projeto enviado à IA
+const maxProjectFileSize = 500 * 1024
+
+func GenerateAnalysis() (*ai.Completion, error) {
 	projectName := getProjectName()
 	files, err := getProjectFiles(".")
 	if err != nil {
-		return fmt.Errorf("erro ao obter arquivos do projeto: %w", err)
+		return nil, i18n.Errorf("analysis.files_error", err)
 	}
 
 	if len(files) == 0 {
-		return errors.New("nenhum arquivo relevante encontrado no projeto")
+		return nil, errors.New(i18n.T("analysis.no_files"))
 	}
 
 	fileContents, err := readProjectFiles(files)
 	if err != nil {
-		return fmt.Errorf("erro ao ler arquivos do projeto: %w", err)
+		return nil, i18n.Errorf("analysis.read_error", err)
 	}
 
 	prompt := buildAnalysisPrompt(fileContents, projectName)
+	prompt += i18n.PromptInstruction(i18n.Portuguese)
 	prompt = minifyPrompt(prompt)
 
 	if err := logPrompt(prompt); err != nil {
-		return fmt.Errorf("erro ao gravar log da análise: %w", err)
+		return nil, i18n.Errorf("analysis.log_error", err)
 	}
 
-	// Carrega configuração
-	config, err := commons.LoadConfig()
+	completion, err := ai.Complete(prompt)
 	if err != nil {
-		return fmt.Errorf("erro ao carregar configuração: %w", err)
-	}
-
-	// Obtém o provedor de IA configurado
-	provider, exists := ai.GetProvider(config.AIProvider)
-	if !exists {
-		provider = ai.GetDefaultProvider()
+		return nil, i18n.Errorf("analysis.response_error", err)
 	}
 
-	response, err := provider.GetCompletions(prompt, config.AIModel)
-	if err != nil {
-		return fmt.Errorf("erro ao obter resposta da OpenAI: %w", err)
-	}
-
-	fmt.Println(response)
-	return nil
+	return completion, nil
 }
 
 func getProjectFiles(root string) ([]string, error) {
@@ -90,28 +85,32 @@ func readProjectFiles(files []string) (map[string]string, error) {
 
 	for _, file := range files {
 		if strings.HasSuffix(file, ".csproj") {
-			log.Printf("ignorando arquivo .csproj: %s", file)
+			log.Print(i18n.T("analysis.skip_csproj", file))
 			continue
 		}
 		if strings.Contains(file, "/bin/") || strings.Contains(file, "/obj/") ||
 			strings.HasPrefix(file, "bin/") || strings.HasPrefix(file, "obj/") {
-			log.Printf("ignorando diretório bin/ ou obj/: %s", file)
+			log.Print(i18n.T("analysis.skip_bin", file))
 			continue
 		}
 		if strings.Contains(file, "/.idea/") || strings.Contains(file, "/.vscode/") ||
 			strings.HasPrefix(file, ".idea/") || strings.HasPrefix(file, ".vscode/") {
-			log.Printf("ignorando diretório .idea/ ou .vscode/: %s", file)
+			log.Print(i18n.T("analysis.skip_ide", file))
 			continue
 		}
 
 		content, err := os.ReadFile(file)
 		if err != nil {
-			log.Printf("erro ao ler arquivo %s: %v", file, err)
+			log.Print(i18n.T("analysis.file_read_error", file, err))
+			continue
+		}
+
+		if isBinary(content) || len(content) > maxProjectFileSize {
+			log.Print(i18n.T("analysis.skip_binary", file))
 			continue
 		}
 
-		if isBinary(content) || len(content) > 500*1024 {
-			log.Printf("ignorando arquivo binário ou muito grande: %s", file)
+		if !policy.Allowed(file) {
 			continue
 		}
 
@@ -121,6 +120,16 @@ func readProjectFiles(files []string) (map[string]string, error) {
 	return fileContents, nil
 }
 
+// sortedPaths retorna os caminhos do mapa em ordem alfabética
+func sortedPaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
 func buildAnalysisPrompt(files map[string]string, projectName string) string {
 	var builder strings.Builder
 
@@ -139,8 +148,9 @@ func buildAnalysisPrompt(files map[string]string, projectName string) string {
 
 	var ciCdFiles []string
 
-	for fileName, content := range files {
-		chunks := chunkString(content, chunkSize)
+	// Os arquivos seguem em ordem alfabética para que o mesmo projeto gere o mesmo prompt (e acerte o cache)
+	for _, fileName := range sortedPaths(files) {
+		chunks := chunkString(files[fileName], chunkSize)
 		for i, chunk := range chunks {
 			builder.WriteString(fmt.Sprintf("## Arquivo: %s (parte %d)\n", fileName, i+1))
 			builder.WriteString("```yaml\n")
@@ -178,7 +188,7 @@ func buildAnalysisPrompt(files map[string]string, projectName string) string {
 	prompt := builder.String()
 	err := logPrompt(prompt)
 	if err != nil {
-		fmt.Printf("Erro ao gravar log da análise: %v\n", err)
+		output.Progress(i18n.T("analysis.log_warning", err))
 	}
 
 	return prompt
@@ -187,18 +197,21 @@ func buildAnalysisPrompt(files map[string]string, projectName string) string {
 func logPrompt(prompt string) error {
 	logDir := filepath.Join(os.Getenv("HOME"), ".log")
 	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return fmt.Errorf("erro ao criar diretório de logs: %w", err)
+		return i18n.Errorf("analysis.log_dir_error", err)
 	}
 	timestamp := time.Now().Format("20060102-150405")
 	logFile := filepath.Join(logDir, fmt.Sprintf("%s-gojira-analysis.log", timestamp))
+	if dryrun.Skip(i18n.T("dryrun.write_file", logFile)) {
+		return nil
+	}
 
 	file, err := os.Create(logFile)
 	if err != nil {
-		return fmt.Errorf("erro ao criar arquivo de log: %w", err)
+		return i18n.Errorf("analysis.log_file_error", err)
 	}
 	defer func(file *os.File) {
 		if ferr := file.Close(); ferr != nil {
-			log.Printf("erro ao fechar arquivo de log: %v", ferr)
+			log.Print(i18n.T("analysis.log_close_error", ferr))
 		}
 	}(file)
 
@@ -245,8 +258,8 @@ func isBinary(content []byte) bool {
 func getProjectName() string {
 	wd, err := os.Getwd()
 	if err != nil {
-		log.Printf("Erro ao obter diretório atual: %v", err)
-		return "Projeto Desconhecido"
+		log.Print(i18n.T("analysis.wd_error", err))
+		return i18n.T("analysis.unknown_project")
 	}
 	return filepath.Base(wd)
 }
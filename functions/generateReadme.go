@@ -9,38 +9,44 @@ import (
 	"strings"
 
 	"gojira/services/ai"
-	"gojira/utils/commons"
+	"gojira/utils/dryrun"
 	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
 )
 
-func GenerateReadme() error {
+// ReadmeFile é o arquivo gerado por GenerateReadme
+const ReadmeFile = "README.md"
+
+func GenerateReadme() (*ai.Completion, error) {
 	isRepo, err := git.IsGitRepository()
 	if err != nil {
-		return fmt.Errorf("erro ao verificar repositório Git: %v", err)
+		return nil, i18n.Errorf("readme.repo_check_error", err)
 	}
 	if !isRepo {
-		return errors.New("diretório não é um repositório Git")
+		return nil, errors.New(i18n.T("readme.not_repo"))
 	}
 
 	treeOutput, err := exec.Command("tree", "-L", "2").Output()
 	if err != nil {
-		return fmt.Errorf("erro ao executar comando tree: %v", err)
+		return nil, i18n.Errorf("readme.tree_error", err)
 	}
 
 	filesData, err := getRepoFilesDetails(".")
 	if err != nil {
-		return fmt.Errorf("erro ao obter detalhes dos arquivos: %v", err)
+		return nil, i18n.Errorf("readme.files_error", err)
 	}
 
 	analysisFilesData, err := getAnalysisFiles(".")
 	if err != nil {
-		return fmt.Errorf("erro ao obter detalhes dos arquivos de análise: %v", err)
+		return nil, i18n.Errorf("readme.analysis_files_error", err)
 	}
 
 	prompt := fmt.Sprintf(
 		"You are an AI assistant specialized in technical documentation.\n\n"+
 			"Analyze the project structure and generate a well-structured README.md following best practices. "+
-			"Ensure the README is written in **US English** and includes the following sections:\n\n"+
+			"Ensure the README is written in **%s** and includes the following sections:\n\n"+
 			"1. **Project Name** - Name and status.\n"+
 			"2. **Description** - Summary of the project's purpose and functionality.\n"+
 			"3. **Technologies Used** - List of main technologies.\n"+
@@ -53,6 +59,7 @@ func GenerateReadme() error {
 			"Use the project file structure below to generate the correct documentation:\n\n"+
 			"**Project Structure:**\n\n%s\n\n"+
 			"**File Details:**\n\n%s\n\n",
+		i18n.LanguageName(i18n.ContentLanguage(i18n.English)),
 		treeOutput,
 		filesData,
 	)
@@ -63,30 +70,22 @@ func GenerateReadme() error {
 
 	prompt += "Generate a README that is well-formatted and correctly structured using Markdown."
 
-	// Carrega configuração
-	config, err := commons.LoadConfig()
+	completion, err := ai.Complete(prompt)
 	if err != nil {
-		return fmt.Errorf("erro ao carregar configuração: %w", err)
-	}
-
-	// Obtém o provedor de IA configurado
-	provider, exists := ai.GetProvider(config.AIProvider)
-	if !exists {
-		provider = ai.GetDefaultProvider()
+		return nil, err
 	}
 
-	readmeContent, err := provider.GetCompletions(prompt, config.AIModel)
-	if err != nil {
-		return err
+	if dryrun.Skip(i18n.T("dryrun.write_file", ReadmeFile)) {
+		return completion, nil
 	}
 
-	err = os.WriteFile("README.md", []byte(readmeContent), 0644)
+	err = os.WriteFile(ReadmeFile, []byte(completion.Text), 0644)
 	if err != nil {
-		return fmt.Errorf("erro ao salvar README.md: %v", err)
+		return nil, i18n.Errorf("readme.save_error", err)
 	}
 
-	fmt.Println("README.md gerado com sucesso!")
-	return nil
+	output.Progress(i18n.T("readme.generated"))
+	return completion, nil
 }
 
 func getRepoFilesDetails(baseDir string) (string, error) {
@@ -110,7 +109,7 @@ func getRepoFilesDetails(baseDir string) (string, error) {
 
 		if !info.IsDir() {
 			data, err := os.ReadFile(path)
-			if err != nil {
+			if err != nil || !policy.Allowed(path) {
 				return nil
 			}
 
@@ -139,7 +138,7 @@ func getAnalysisFiles(baseDir string) (string, error) {
 	for _, entry := range entries {
 		if !entry.IsDir() && strings.HasPrefix(entry.Name(), "analysis_") {
 			data, err := os.ReadFile(filepath.Join(baseDir, entry.Name()))
-			if err != nil {
+			if err != nil || !policy.Allowed(filepath.Join(baseDir, entry.Name())) {
 				continue
 			}
 
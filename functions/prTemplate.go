@@ -0,0 +1,208 @@
+package functions
+
+import (
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PRTemplate representa um modelo de descrição de pull request encontrado no repositório
+type PRTemplate struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Content string `json:"-"`
+}
+
+// prTemplateFiles são os modelos únicos, na ordem em que o GitHub e o Gitea os procuram
+var prTemplateFiles = []string{
+	".github/pull_request_template.md",
+	"pull_request_template.md",
+	"docs/pull_request_template.md",
+	".gitea/pull_request_template.md",
+}
+
+// prTemplateDirs são os diretórios com vários modelos, um por arquivo
+var prTemplateDirs = []string{
+	".github/PULL_REQUEST_TEMPLATE",
+	"PULL_REQUEST_TEMPLATE",
+	"docs/PULL_REQUEST_TEMPLATE",
+	".gitlab/merge_request_templates",
+}
+
+// FindPRTemplates procura os modelos de descrição de PR do GitHub, do GitLab e do Gitea a partir
+// da raiz do repositório. Os nomes de arquivos e diretórios não diferenciam maiúsculas.
+func FindPRTemplates(root string) ([]*PRTemplate, error) {
+	templates := []*PRTemplate{}
+
+	for _, location := range prTemplateFiles {
+		path, found := findInsensitive(root, location)
+		if !found {
+			continue
+		}
+		template, err := readPRTemplate(path, "default")
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	for _, location := range prTemplateDirs {
+		dir, found := findInsensitive(root, location)
+		if !found {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		names := []string{}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+				names = append(names, entry.Name())
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			template, err := readPRTemplate(filepath.Join(dir, name), strings.TrimSuffix(name, filepath.Ext(name)))
+			if err != nil {
+				return nil, err
+			}
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
+// SelectPRTemplate escolhe o modelo pelo nome (sem .md) ou pelo caminho
+func SelectPRTemplate(templates []*PRTemplate, choice string) (*PRTemplate, error) {
+	for _, template := range templates {
+		if strings.EqualFold(template.Name, choice) || template.Path == choice {
+			return template, nil
+		}
+	}
+
+	// Um caminho fora dos locais padrão também é aceito
+	if info, err := os.Stat(choice); err == nil && !info.IsDir() {
+		return readPRTemplate(choice, strings.TrimSuffix(filepath.Base(choice), filepath.Ext(choice)))
+	}
+	return nil, i18n.Errorf("pr.template_not_found", choice)
+}
+
+// readPRTemplate lê o conteúdo de um modelo
+func readPRTemplate(path, name string) (*PRTemplate, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("pr.template_read_error", path, err)
+	}
+	return &PRTemplate{Name: name, Path: path, Content: string(content)}, nil
+}
+
+// findInsensitive localiza um caminho relativo à raiz comparando cada parte sem diferenciar maiúsculas
+func findInsensitive(root, location string) (string, bool) {
+	current := root
+	for _, part := range strings.Split(location, "/") {
+		entries, err := os.ReadDir(current)
+		if err != nil {
+			return "", false
+		}
+		found := false
+		for _, entry := range entries {
+			if strings.EqualFold(entry.Name(), part) {
+				current = filepath.Join(current, entry.Name())
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+	return current, true
+}
+
+var (
+	checkboxPattern = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s+(.+?)\s*$`)
+	headingPattern  = regexp.MustCompile(`^\s*#{1,6}\s+(.+?)\s*#*\s*$`)
+)
+
+// PreserveCheckboxes devolve à descrição os itens de checklist do modelo que a IA omitiu,
+// desmarcados e na seção de mesmo título; sem a seção, eles vão para o final.
+func PreserveCheckboxes(description, template string) string {
+	present := map[string]bool{}
+	for _, line := range strings.Split(description, "\n") {
+		if match := checkboxPattern.FindStringSubmatch(line); match != nil {
+			present[strings.ToLower(match[1])] = true
+		}
+	}
+
+	// Agrupa os itens ausentes pelo título da seção em que aparecem no modelo
+	missing := map[string][]string{}
+	order := []string{}
+	heading := ""
+	for _, line := range strings.Split(template, "\n") {
+		if match := headingPattern.FindStringSubmatch(line); match != nil {
+			heading = strings.ToLower(match[1])
+			continue
+		}
+		match := checkboxPattern.FindStringSubmatch(line)
+		if match == nil || present[strings.ToLower(match[1])] {
+			continue
+		}
+		if _, seen := missing[heading]; !seen {
+			order = append(order, heading)
+		}
+		missing[heading] = append(missing[heading], "- [ ] "+match[1])
+	}
+	if len(order) == 0 {
+		return description
+	}
+
+	lines := strings.Split(strings.TrimRight(description, "\n"), "\n")
+	trailing := []string{}
+	for _, section := range order {
+		items := missing[section]
+		index := sectionEnd(lines, section)
+		if index < 0 {
+			trailing = append(trailing, items...)
+			continue
+		}
+		lines = append(lines[:index], append(items, lines[index:]...)...)
+	}
+	if len(trailing) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, trailing...)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// sectionEnd retorna a posição logo após o último conteúdo da seção com o título, ou -1 se ela não existir
+func sectionEnd(lines []string, heading string) int {
+	if heading == "" {
+		return -1
+	}
+
+	start := -1
+	for i, line := range lines {
+		if match := headingPattern.FindStringSubmatch(line); match != nil && strings.ToLower(match[1]) == heading {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return -1
+	}
+
+	end := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		if headingPattern.MatchString(lines[i]) {
+			break
+		}
+		if strings.TrimSpace(lines[i]) != "" {
+			end = i + 1
+		}
+	}
+	return end
+}
@@ -0,0 +1,279 @@
+package functions
+
+import (
+	"fmt"
+	"gojira/services/ai"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// minFileBudget é o menor número de tokens de diff que vale a pena enviar para resumir um arquivo
+const minFileBudget = 200
+
+// FileSummary representa o resumo das alterações de um arquivo
+type FileSummary struct {
+	Path      string `json:"path"`
+	Summary   string `json:"summary"`
+	Truncated bool   `json:"truncated"`
+	Skipped   bool   `json:"skipped"`
+}
+
+// DiffSummary representa os resumos por arquivo e os impactos encontrados nas alterações
+type DiffSummary struct {
+	Files           []*FileSummary `json:"files"`
+	BreakingChanges []string       `json:"breaking_changes"`
+	Migrations      []string       `json:"migrations"`
+	ConfigChanges   []string       `json:"config_changes"`
+	Tokens          int            `json:"tokens"`
+}
+
+// fileSummaryResponse representa a resposta estruturada do resumo de um arquivo
+type fileSummaryResponse struct {
+	Summary         string   `json:"summary" description:"What changed in the file and why, in one to three sentences"`
+	BreakingChanges []string `json:"breaking_changes" description:"Changes that break compatibility: removed or renamed public APIs, changed signatures, behaviour, endpoints or CLI flags; empty if none"`
+	Migrations      []string `json:"migrations" description:"Database, schema or data migrations and the steps they require; empty if none"`
+	ConfigChanges   []string `json:"config_changes" description:"Added, removed or changed configuration options, environment variables or defaults; empty if none"`
+}
+
+// SummarizeDiff resume em paralelo as alterações de cada arquivo, respeitando um orçamento total de
+// tokens para os diffs enviados. Os arquivos menores são resumidos por inteiro; o que sobra do
+// orçamento é dividido entre os maiores, que são truncados, e os que não cabem são apenas listados.
+func SummarizeDiff(diffs map[string]string, budget, parallel int) (*DiffSummary, error) {
+	paths := make([]string, 0, len(diffs))
+	for path := range diffs {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return len(diffs[paths[i]]) < len(diffs[paths[j]])
+	})
+
+	// Distribui o orçamento: cada arquivo recebe no máximo a parte igual do que ainda resta
+	limits := map[string]int{}
+	remaining := budget
+	summary := &DiffSummary{}
+	for i, path := range paths {
+		share := remaining / (len(paths) - i)
+		tokens := dryrun.EstimateTokens(diffs[path])
+		if tokens > share {
+			tokens = share
+		}
+		if tokens < minFileBudget && tokens < dryrun.EstimateTokens(diffs[path]) {
+			tokens = 0
+		}
+		limits[path] = tokens
+		remaining -= tokens
+		summary.Tokens += tokens
+	}
+
+	// As respostas simuladas do --dry-run são exibidas uma a uma para não se misturarem
+	if parallel < 1 || dryrun.IsEnabled() {
+		parallel = 1
+	}
+
+	sort.Strings(paths)
+	results := make([]*fileSummaryResponse, len(paths))
+	summary.Files = make([]*FileSummary, len(paths))
+	errs := make([]error, len(paths))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, parallel)
+	for i, path := range paths {
+		file := &FileSummary{Path: path}
+		summary.Files[i] = file
+		if limits[path] == 0 {
+			file.Skipped = true
+			continue
+		}
+
+		diff := diffs[path]
+		if dryrun.EstimateTokens(diff) > limits[path] {
+			diff = truncateDiff(diff, limits[path]*4)
+			file.Truncated = true
+		}
+
+		wg.Add(1)
+		go func(i int, file *FileSummary, diff string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			output.Progress(i18n.T("pr.summarizing_file", file.Path))
+			var response fileSummaryResponse
+			completion, err := ai.CompleteJSON(buildFileSummaryPrompt(file.Path, diff, file.Truncated), &response)
+			if err != nil {
+				errs[i] = i18n.Errorf("pr.file_summary_error", file.Path, err)
+				return
+			}
+			if completion.DryRun {
+				file.Summary = completion.Text
+				return
+			}
+			file.Summary = strings.TrimSpace(response.Summary)
+			results[i] = &response
+		}(i, file, diff)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Arquivos de migração e de configuração entram nas seções mesmo que a IA não os aponte
+	for i, path := range paths {
+		response := results[i]
+		if response != nil {
+			summary.BreakingChanges = append(summary.BreakingChanges, prefixAll(path, response.BreakingChanges)...)
+			summary.Migrations = append(summary.Migrations, prefixAll(path, response.Migrations)...)
+			summary.ConfigChanges = append(summary.ConfigChanges, prefixAll(path, response.ConfigChanges)...)
+		}
+		if isMigrationFile(path) && (response == nil || len(response.Migrations) == 0) {
+			summary.Migrations = append(summary.Migrations, fmt.Sprintf("`%s`", path))
+		}
+		if isConfigFile(path) && (response == nil || len(response.ConfigChanges) == 0) {
+			summary.ConfigChanges = append(summary.ConfigChanges, fmt.Sprintf("`%s`", path))
+		}
+	}
+	return summary, nil
+}
+
+// Text formata os resumos e os impactos para o prompt da descrição
+func (s *DiffSummary) Text() string {
+	var sb strings.Builder
+	for _, file := range s.Files {
+		switch {
+		case file.Skipped:
+			sb.WriteString(fmt.Sprintf("- %s: (diff not summarized, over the token budget)\n", file.Path))
+		case file.Truncated:
+			sb.WriteString(fmt.Sprintf("- %s (partial diff): %s\n", file.Path, file.Summary))
+		default:
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", file.Path, file.Summary))
+		}
+	}
+	for _, section := range s.sections() {
+		if len(section.items) > 0 {
+			sb.WriteString("\n" + section.label + ":\n- " + strings.Join(section.items, "\n- ") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// impactSection representa uma seção dedicada da descrição
+type impactSection struct {
+	label   string
+	heading string
+	items   []string
+}
+
+// sections retorna as seções de impacto com os títulos usados na descrição, no idioma do conteúdo
+func (s *DiffSummary) sections() []impactSection {
+	lang := i18n.ContentLanguage(i18n.Portuguese)
+	return []impactSection{
+		{"Breaking changes", i18n.TIn(lang, "pr.section_breaking"), s.BreakingChanges},
+		{"Migrations", i18n.TIn(lang, "pr.section_migrations"), s.Migrations},
+		{"Configuration changes", i18n.TIn(lang, "pr.section_config"), s.ConfigChanges},
+	}
+}
+
+// PreserveImpactSections acrescenta à descrição as seções de breaking changes, migrações e
+// configuração que tiverem itens e que a IA não tiver escrito
+func PreserveImpactSections(description string, summary *DiffSummary) string {
+	lines := strings.Split(description, "\n")
+	for _, section := range summary.sections() {
+		if len(section.items) == 0 || sectionEnd(lines, strings.ToLower(section.heading)) >= 0 {
+			continue
+		}
+		description = strings.TrimRight(description, "\n") + "\n\n## " + section.heading + "\n\n- " +
+			strings.Join(section.items, "\n- ") + "\n"
+	}
+	return description
+}
+
+// ImpactInstruction retorna a instrução para a IA escrever as seções de impacto não vazias
+func (s *DiffSummary) ImpactInstruction() string {
+	headings := []string{}
+	for _, section := range s.sections() {
+		if len(section.items) > 0 {
+			headings = append(headings, "\"## "+section.heading+"\"")
+		}
+	}
+	if len(headings) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Include dedicated sections titled exactly %s listing every corresponding item given above; "+
+		"do not add these sections for categories without items.", strings.Join(headings, ", "))
+}
+
+// buildFileSummaryPrompt monta o prompt do resumo de um arquivo
+func buildFileSummaryPrompt(path, diff string, truncated bool) string {
+	prompt := fmt.Sprintf(
+		"Summarize the changes in the file %s from the Git diff below, for a reviewer of the pull request. "+
+			"Focus on behaviour and intent rather than restating lines. Report breaking changes, migrations "+
+			"and configuration changes only when the diff shows them.\n\n```diff\n%s\n```",
+		path, diff,
+	)
+	if truncated {
+		prompt += "\n\nThe diff was cut to fit the token budget; summarize only what is shown."
+	}
+	return prompt + i18n.PromptInstruction(i18n.Portuguese)
+}
+
+// truncateDiff corta o diff no último hunk completo que cabe no limite de bytes ou, se nem o primeiro
+// hunk couber, na última linha completa
+func truncateDiff(diff string, limit int) string {
+	if len(diff) <= limit {
+		return diff
+	}
+	for limit > 0 && !utf8.RuneStart(diff[limit]) {
+		limit--
+	}
+	cut := diff[:limit]
+	if index := strings.LastIndex(cut, "\n@@"); index > 0 {
+		cut = cut[:index]
+	} else if index := strings.LastIndex(cut, "\n"); index > 0 {
+		cut = cut[:index]
+	}
+	return cut + "\n[...]"
+}
+
+// prefixAll identifica cada item com o arquivo de origem
+func prefixAll(path string, items []string) []string {
+	prefixed := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			prefixed = append(prefixed, fmt.Sprintf("`%s`: %s", path, item))
+		}
+	}
+	return prefixed
+}
+
+// isMigrationFile indica se o arquivo parece ser uma migração de banco de dados
+func isMigrationFile(path string) bool {
+	lower := strings.ToLower(filepath.ToSlash(path))
+	return strings.Contains(lower, "migration") || strings.Contains(lower, "/migrate/") ||
+		strings.HasPrefix(lower, "migrate/") || strings.HasSuffix(lower, ".sql")
+}
+
+// isConfigFile indica se o arquivo parece ser de configuração
+func isConfigFile(path string) bool {
+	lower := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.HasPrefix(lower, ".env"), strings.HasPrefix(lower, "docker-compose"):
+		return true
+	case strings.Contains(lower, "config") || strings.Contains(lower, "settings"):
+		ext := filepath.Ext(lower)
+		return ext != ".go" && ext != ".py" && ext != ".js" && ext != ".ts" && ext != ".java"
+	}
+	switch filepath.Ext(lower) {
+	case ".yaml", ".yml", ".toml", ".ini", ".conf", ".properties":
+		return true
+	}
+	return false
+}
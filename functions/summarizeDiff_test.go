@@ -0,0 +1,49 @@
+package functions
+
+import (
+	"gojira/utils/i18n"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateDiff(t *testing.T) {
+	diff := "@@ -1 +1 @@\n-a\n+ação\n@@ -9 +9 @@\n-b\n+c\n"
+	if got := truncateDiff(diff, len(diff)); got != diff {
+		t.Errorf("diff dentro do limite alterado: %q", got)
+	}
+
+	// Corta no último hunk completo
+	if got := truncateDiff(diff, strings.Index(diff, "-b")); got != "@@ -1 +1 @@\n-a\n+ação\n[...]" {
+		t.Errorf("truncateDiff = %q", got)
+	}
+
+	// Sem hunk completo, corta na última linha, sem partir caracteres de vários bytes
+	for limit := 1; limit < len(diff); limit++ {
+		got := truncateDiff(diff[strings.Index(diff, "\n")+1:], limit)
+		if !utf8.ValidString(got) {
+			t.Fatalf("truncateDiff(%d) partiu um caractere: %q", limit, got)
+		}
+	}
+	if got := truncateDiff("-a\n+ação\n+b\n", 12); got != "-a\n+ação\n[...]" {
+		t.Errorf("truncateDiff = %q", got)
+	}
+}
+
+func TestImpactSectionsFollowContentLanguage(t *testing.T) {
+	summary := &DiffSummary{Migrations: []string{"`db/001.sql`"}}
+	defer i18n.OverrideContentLanguage(i18n.Portuguese)
+
+	i18n.OverrideContentLanguage(i18n.English)
+	if got := PreserveImpactSections("## Summary\n", summary); !strings.Contains(got, "## Migrations\n") {
+		t.Errorf("seção em inglês ausente:\n%s", got)
+	}
+
+	i18n.OverrideContentLanguage(i18n.Portuguese)
+	if got := summary.ImpactInstruction(); !strings.Contains(got, `"## Migrações"`) {
+		t.Errorf("instrução = %q", got)
+	}
+	if got := PreserveImpactSections("## Resumo\n\n## Migrações\n\n- db\n", summary); strings.Count(got, "Migrações") != 1 {
+		t.Errorf("seção existente repetida:\n%s", got)
+	}
+}
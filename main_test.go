@@ -0,0 +1,361 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// binary é o executável do gojira compilado para os testes de ponta a ponta
+var binary string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "gojira-e2e")
+	if err != nil {
+		panic(err)
+	}
+	binary = filepath.Join(dir, "gojira")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	if output, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput(); err != nil {
+		panic("go build: " + err.Error() + "\n" + string(output))
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// e2e representa um ambiente isolado: um repositório Git, a home com a configuração e o
+// diretório de dados do gojira
+type e2e struct {
+	t       *testing.T
+	repo    string
+	home    string
+	dataDir string
+	env     map[string]string
+}
+
+// result representa a saída de gojira --output json
+type result struct {
+	Command  string `json:"command"`
+	Text     string `json:"text"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Cached   bool   `json:"cached"`
+	Error    string `json:"error"`
+
+	Files  []string               `json:"files"`
+	Data   map[string]interface{} `json:"data"`
+	DryRun *struct {
+		Prompts []struct {
+			Provider string `json:"provider"`
+		} `json:"prompts"`
+		Actions []string `json:"actions"`
+	} `json:"dry_run"`
+}
+
+func newE2E(t *testing.T, provider string) *e2e {
+	root := t.TempDir()
+	env := &e2e{
+		t:       t,
+		repo:    filepath.Join(root, "repo"),
+		home:    filepath.Join(root, "home"),
+		dataDir: filepath.Join(root, "data"),
+		env:     map[string]string{},
+	}
+	for _, dir := range []string{env.repo, env.home} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := `{"ai_provider": "` + provider + `", "ui_language": "en"}`
+	if err := os.WriteFile(filepath.Join(env.home, ".gojira.json"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env.git("init", "-q")
+	env.git("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	env.git("checkout", "-q", "-b", "feature/ABC-123-login")
+	if err := os.WriteFile(filepath.Join(env.repo, "login.go"), []byte("package app\n\nfunc Login() bool { return true }\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	env.git("add", "login.go")
+	return env
+}
+
+func (e *e2e) git(args ...string) {
+	e.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = e.repo
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=gojira", "GIT_AUTHOR_EMAIL=gojira@example.com",
+		"GIT_COMMITTER_NAME=gojira", "GIT_COMMITTER_EMAIL=gojira@example.com")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		e.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+}
+
+// run executa o gojira no repositório com --output json, devolvendo o resultado e o stderr
+func (e *e2e) run(args ...string) (*result, string, error) {
+	e.t.Helper()
+	stdout, stderr, err := e.exec(append(args, "--output", "json")...)
+
+	var parsed result
+	if jsonErr := json.Unmarshal([]byte(stdout), &parsed); jsonErr != nil {
+		e.t.Fatalf("saída JSON inválida (%v):\n%s\nstderr:\n%s", jsonErr, stdout, stderr)
+	}
+	return &parsed, stderr, err
+}
+
+// exec executa o gojira no repositório, sem as chaves de API nem a configuração do usuário
+func (e *e2e) exec(args ...string) (string, string, error) {
+	e.t.Helper()
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = e.repo
+
+	cmd.Env = []string{}
+	for _, variable := range os.Environ() {
+		name, _, _ := strings.Cut(variable, "=")
+		if strings.HasPrefix(name, "GOJIRA_") || strings.HasSuffix(name, "_API_KEY") || name == "HOME" {
+			continue
+		}
+		cmd.Env = append(cmd.Env, variable)
+	}
+	cmd.Env = append(cmd.Env, "HOME="+e.home, "GOJIRA_DATA_DIR="+e.dataDir)
+	for name, value := range e.env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestCommitWithMockProvider(t *testing.T) {
+	env := newE2E(t, "mock")
+	env.env["GOJIRA_MOCK_SCRIPT"] = filepath.Join(env.home, "mock.json")
+	script := `[{"match": "feature/ABC-123-login[\\s\\S]*func Login", "response": "feat: [ABC-123] Add login"}]`
+	if err := os.WriteFile(env.env["GOJIRA_MOCK_SCRIPT"], []byte(script), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output, stderr, err := env.run("commit")
+	if err != nil {
+		t.Fatalf("gojira commit: %v\n%s", err, stderr)
+	}
+	if output.Command != "commit" || output.Text != "feat: [ABC-123] Add login" || output.Provider != "Mock" {
+		t.Errorf("resultado = %+v", output)
+	}
+
+	// O histórico vai para GOJIRA_DATA_DIR, e nada é gravado na home
+	entries, _ := os.ReadDir(filepath.Join(env.dataDir, "history"))
+	if len(entries) != 1 {
+		t.Errorf("%d gerações no histórico, esperada 1", len(entries))
+	}
+	if _, err := os.Stat(filepath.Join(env.home, ".gojira")); !os.IsNotExist(err) {
+		t.Errorf("dados gravados na home: %v", err)
+	}
+
+	// A segunda execução vem do cache
+	output, stderr, err = env.run("commit")
+	if err != nil {
+		t.Fatalf("gojira commit: %v\n%s", err, stderr)
+	}
+	if !output.Cached || output.Text != "feat: [ABC-123] Add login" {
+		t.Errorf("resultado em cache = %+v", output)
+	}
+}
+
+func TestCommitRecordsAndReplaysFixtures(t *testing.T) {
+	env := newE2E(t, "fixture")
+	env.env["GOJIRA_FIXTURES_DIR"] = filepath.Join(env.home, "fixtures")
+	env.env["GOJIRA_FIXTURES_PROVIDER"] = "mock"
+
+	env.env["GOJIRA_FIXTURES_MODE"] = "record"
+	env.env["GOJIRA_MOCK_RESPONSE"] = "feat: [ABC-123] Recorded"
+	recorded, stderr, err := env.run("commit")
+	if err != nil {
+		t.Fatalf("gravação: %v\n%s", err, stderr)
+	}
+
+	// A reprodução ignora o provedor: a resposta é a gravada, mesmo que o mock mude
+	env.env["GOJIRA_FIXTURES_MODE"] = "replay"
+	env.env["GOJIRA_MOCK_RESPONSE"] = "feat: [ABC-123] Changed"
+	replayed, stderr, err := env.run("commit", "--no-cache")
+	if err != nil {
+		t.Fatalf("reprodução: %v\n%s", err, stderr)
+	}
+	if replayed.Text != recorded.Text || replayed.Text != "feat: [ABC-123] Recorded" {
+		t.Errorf("resposta reproduzida %q, gravada %q", replayed.Text, recorded.Text)
+	}
+	if replayed.Model != "mock-1" {
+		t.Errorf("modelo = %q, esperado o padrão do provedor gravado", replayed.Model)
+	}
+}
+
+func TestReplayDoesNotRequireProviderKeys(t *testing.T) {
+	env := newE2E(t, "fixture")
+	env.env["GOJIRA_FIXTURES_DIR"] = filepath.Join(env.home, "fixtures")
+	env.env["GOJIRA_FIXTURES_MODE"] = "replay"
+	env.env["GOJIRA_FIXTURES_PROVIDER"] = "openai"
+
+	output, stderr, err := env.run("commit")
+	if err == nil {
+		t.Fatal("esperado erro sem a fixture gravada")
+	}
+	if !strings.Contains(output.Error, "fixture") {
+		t.Errorf("erro = %q, esperado fixture não encontrada", output.Error)
+	}
+	if output.Model != "" || strings.Contains(stderr, "OPENAI_API_KEY") {
+		t.Errorf("o provedor real foi criado no replay:\n%s", stderr)
+	}
+}
+
+func TestCommitHookIsQuiet(t *testing.T) {
+	env := newE2E(t, "mock")
+	message := filepath.Join(env.home, "COMMIT_EDITMSG")
+	if err := os.WriteFile(message, []byte("feat: [ABC-123] Add login\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// O hook roda a cada git commit: uma mensagem válida não gera nenhuma saída
+	stdout, stderr, err := env.exec("lint", "commits", "--message-file", message)
+	if err != nil || stdout != "" || stderr != "" {
+		t.Errorf("hook com mensagem válida: %v\nstdout:\n%s\nstderr:\n%s", err, stdout, stderr)
+	}
+
+	if err := os.WriteFile(message, []byte("added login\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, stderr, err = env.exec("lint", "commits", "--message-file", message)
+	if err == nil || strings.Contains(stderr, ".env") {
+		t.Errorf("hook com mensagem inválida: %v\nstderr:\n%s", err, stderr)
+	}
+}
+
+// existingTests é o arquivo de testes que já existe no repositório dos testes de test-gen
+const existingTests = "package app\n\nimport \"testing\"\n\n// TestExisting já estava no arquivo\nfunc TestExisting(t *testing.T) {}\n"
+
+// generatedTests é a resposta estruturada com o teste gerado para login.go
+const generatedTests = `{"code": "package app\n\nimport \"testing\"\n\nfunc TestLogin(t *testing.T) {\n\tif !Login() {\n\t\tt.Fatal(\"login\")\n\t}\n}\n"}`
+
+func (e *e2e) writeExistingTests() string {
+	e.t.Helper()
+	path := filepath.Join(e.repo, "login_test.go")
+	if err := os.WriteFile(path, []byte(existingTests), 0644); err != nil {
+		e.t.Fatal(err)
+	}
+	return path
+}
+
+func TestTestGenReplaysFixturesIntoExistingTests(t *testing.T) {
+	env := newE2E(t, "fixture")
+	env.env["GOJIRA_FIXTURES_DIR"] = filepath.Join(env.home, "fixtures")
+	env.env["GOJIRA_FIXTURES_PROVIDER"] = "mock"
+	testFile := env.writeExistingTests()
+
+	env.env["GOJIRA_FIXTURES_MODE"] = "record"
+	env.env["GOJIRA_MOCK_RESPONSE"] = generatedTests
+	recorded, stderr, err := env.run("test-gen", "-s", "login.go")
+	if err != nil {
+		t.Fatalf("gravação: %v\n%s", err, stderr)
+	}
+	written, _ := os.ReadFile(testFile)
+	if !strings.Contains(string(written), "func TestExisting") || !strings.Contains(string(written), "func TestLogin") {
+		t.Fatalf("testes não mesclados:\n%s", written)
+	}
+	if len(recorded.Files) != 1 || recorded.Files[0] != "login_test.go" {
+		t.Errorf("arquivos = %v", recorded.Files)
+	}
+
+	// A reprodução grava o mesmo arquivo, mesmo que o mock passe a responder outra coisa
+	env.writeExistingTests()
+	env.env["GOJIRA_FIXTURES_MODE"] = "replay"
+	env.env["GOJIRA_MOCK_RESPONSE"] = `{"code": "package other\n"}`
+	if _, stderr, err := env.run("test-gen", "-s", "login.go", "--no-cache"); err != nil {
+		t.Fatalf("reprodução: %v\n%s", err, stderr)
+	}
+	replayed, _ := os.ReadFile(testFile)
+	if string(replayed) != string(written) {
+		t.Errorf("arquivo reproduzido:\n%s\nesperado:\n%s", replayed, written)
+	}
+}
+
+func TestTestGenMergesTestsThePolicyKeepsFromTheProvider(t *testing.T) {
+	env := newE2E(t, "mock")
+	env.env["GOJIRA_MOCK_RESPONSE"] = generatedTests
+	testFile := env.writeExistingTests()
+	policy := `{"deny": ["*_test.go"]}`
+	if err := os.WriteFile(filepath.Join(env.repo, ".gojira-policy.json"), []byte(policy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, stderr, err := env.run("test-gen", "-s", "login.go"); err != nil {
+		t.Fatalf("gojira test-gen: %v\n%s", err, stderr)
+	}
+
+	// O arquivo negado não vai no prompt, mas os testes existentes continuam nele
+	written, _ := os.ReadFile(testFile)
+	if !strings.Contains(string(written), "// TestExisting já estava no arquivo") || !strings.Contains(string(written), "func TestLogin") {
+		t.Errorf("testes não mesclados:\n%s", written)
+	}
+	entries, _ := os.ReadDir(filepath.Join(env.dataDir, "history"))
+	if len(entries) == 0 {
+		t.Fatal("nenhuma geração no histórico")
+	}
+	for _, entry := range entries {
+		data, _ := os.ReadFile(filepath.Join(env.dataDir, "history", entry.Name()))
+		if strings.Contains(string(data), "TestExisting") {
+			t.Errorf("o conteúdo do arquivo negado foi enviado: %s", entry.Name())
+		}
+	}
+}
+
+func TestPRDryRunResolvesCodeHost(t *testing.T) {
+	env := newE2E(t, "fixture")
+	env.env["GOJIRA_FIXTURES_DIR"] = filepath.Join(env.home, "fixtures")
+	env.env["GOJIRA_FIXTURES_MODE"] = "replay"
+	env.env["GOJIRA_FIXTURES_PROVIDER"] = "openai"
+	env.env["GITHUB_TOKEN"] = "secret"
+	env.git("branch", "main", "HEAD")
+	env.git("commit", "-q", "-m", "feat: [ABC-123] Add login")
+
+	tests := []struct {
+		remote string
+		via    string
+	}{
+		{"https://github.com/acme/app.git", "api"},
+		// Um alias SSH com github no nome não recebe o token
+		{"git@github-work:acme/app.git", "cli"},
+	}
+	for i, test := range tests {
+		if i == 0 {
+			env.git("remote", "add", "origin", test.remote)
+		} else {
+			env.git("remote", "set-url", "origin", test.remote)
+		}
+
+		output, stderr, err := env.run("pr", "--dry-run", "--base", "main", "--no-jira")
+		if err != nil {
+			t.Fatalf("gojira pr --dry-run (%s): %v\n%s", test.remote, err, stderr)
+		}
+		if output.Data["code_host"] != "github" || output.Data["via"] != test.via {
+			t.Errorf("%s: dados = %v, esperado github por %s", test.remote, output.Data, test.via)
+		}
+		if output.DryRun == nil || len(output.DryRun.Actions) == 0 || len(output.DryRun.Prompts) == 0 {
+			t.Errorf("%s: relatório do dry-run = %+v", test.remote, output.DryRun)
+		}
+		if strings.Contains(stderr, "OPENAI_API_KEY") {
+			t.Errorf("%s: o provedor real foi criado:\n%s", test.remote, stderr)
+		}
+	}
+}
@@ -4,16 +4,20 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"gojira/utils/commons"
+	"gojira/utils/i18n"
 	"io"
 	"net/http"
 	"time"
 )
 
+// anthropicMaxTokens é o limite de tokens das respostas quando nem a requisição nem a configuração o definem
+const anthropicMaxTokens = 4096
+
 // AnthropicProvider implementa a interface Provider para a Anthropic
 type AnthropicProvider struct {
-	apiKey string
+	apiKey    string
+	lastUsage Usage
 }
 
 // NewAnthropicProvider cria uma nova instância do provedor Anthropic
@@ -43,10 +47,18 @@ func (p *AnthropicProvider) GetDefaultModel() string {
 	return "claude-3-5-sonnet-20240620"
 }
 
-// GetCompletions implementa a interface Provider.GetCompletions
-func (p *AnthropicProvider) GetCompletions(prompt string, modelID string) (string, error) {
+// GetLastUsage implementa a interface UsageReporter.GetLastUsage
+func (p *AnthropicProvider) GetLastUsage() Usage {
+	return p.lastUsage
+}
+
+// GetCompletions implementa a interface Provider.GetCompletions.
+// Respostas estruturadas forçam o uso de uma ferramenta cujo input segue o schema.
+func (p *AnthropicProvider) GetCompletions(request *Request, modelID string) (string, error) {
+	p.lastUsage = Usage{}
+
 	if p.apiKey == "" {
-		return "", errors.New("ANTHROPIC_API_KEY não fornecido")
+		return "", errors.New(i18n.T("ai.missing_key", "ANTHROPIC_API_KEY"))
 	}
 
 	if modelID == "" {
@@ -54,10 +66,32 @@ func (p *AnthropicProvider) GetCompletions(prompt string, modelID string) (strin
 	}
 
 	url := "https://api.anthropic.com/v1/messages"
+	messages := []map[string]string{}
+	for _, message := range request.Messages {
+		messages = append(messages, map[string]string{"role": message.Role, "content": message.Content})
+	}
+
 	body := map[string]interface{}{
 		"model":      modelID,
-		"messages":   []map[string]string{{"role": "user", "content": prompt}},
-		"max_tokens": 4096,
+		"messages":   messages,
+		"max_tokens": request.GetMaxTokens(anthropicMaxTokens),
+	}
+	if request.System != "" {
+		body["system"] = request.System
+	}
+	if request.Temperature != nil {
+		body["temperature"] = *request.Temperature
+	}
+	if len(request.Stop) > 0 {
+		body["stop_sequences"] = request.Stop
+	}
+	if request.Schema != nil {
+		body["tools"] = []map[string]interface{}{{
+			"name":         request.Schema.Name,
+			"description":  "Respond with a JSON object matching this schema.",
+			"input_schema": request.Schema.Definition,
+		}}
+		body["tool_choice"] = map[string]interface{}{"type": "tool", "name": request.Schema.Name}
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -85,7 +119,7 @@ func (p *AnthropicProvider) GetCompletions(prompt string, modelID string) (strin
 
 	if resp.StatusCode != http.StatusOK {
 		formattedBody, _ := json.MarshalIndent(body, "", "  ")
-		return "", fmt.Errorf("falha na chamada à API Anthropic (%d): %s", resp.StatusCode, string(formattedBody))
+		return "", errors.New(i18n.T("ai.api_error", p.GetName(), resp.StatusCode, string(formattedBody)))
 	}
 
 	var result map[string]interface{}
@@ -93,13 +127,31 @@ func (p *AnthropicProvider) GetCompletions(prompt string, modelID string) (strin
 		return "", err
 	}
 
-	// A estrutura de resposta da Anthropic é diferente da OpenAI
+	if usage, ok := result["usage"].(map[string]interface{}); ok {
+		p.lastUsage = Usage{
+			InputTokens:  numberToInt(usage["input_tokens"]),
+			OutputTokens: numberToInt(usage["output_tokens"]),
+		}
+	}
+
+	// A estrutura de resposta da Anthropic é diferente da OpenAI; o uso de ferramenta traz o JSON estruturado
 	if content, ok := result["content"].([]interface{}); ok && len(content) > 0 {
+		for _, item := range content {
+			block, _ := item.(map[string]interface{})
+			if block["type"] == "tool_use" {
+				input, err := json.Marshal(block["input"])
+				if err != nil {
+					return "", err
+				}
+				return string(input), nil
+			}
+		}
+
 		firstBlock := content[0].(map[string]interface{})
 		if text, ok := firstBlock["text"].(string); ok {
 			return text, nil
 		}
 	}
 
-	return "", errors.New("resposta inesperada da API Anthropic")
+	return "", errors.New(i18n.T("ai.unexpected_response", p.GetName()))
 }
@@ -0,0 +1,328 @@
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gojira/services/cache"
+	"gojira/services/history"
+	"gojira/utils/commons"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
+	"gojira/utils/redact"
+	"strings"
+	"time"
+)
+
+// Completion representa o resultado de uma geração feita por um provedor de IA
+type Completion struct {
+	Text     string        `json:"text"`
+	Provider string        `json:"provider"`
+	Model    string        `json:"model"`
+	Duration time.Duration `json:"-"`
+	Cached   bool          `json:"cached"`
+	DryRun   bool          `json:"dry_run"`
+	Usage    Usage         `json:"usage"`
+}
+
+// GetConfiguredProvider retorna o provedor e o modelo definidos na configuração
+func GetConfiguredProvider(config *commons.Config) (Provider, string) {
+	provider, exists := GetProvider(config.AIProvider)
+	if !exists {
+		provider = GetDefaultProvider()
+	}
+
+	model := config.AIModel
+	if model == "" {
+		model = provider.GetDefaultModel()
+	}
+
+	return provider, model
+}
+
+// Complete envia o prompt para o provedor configurado e retorna a resposta com seus metadados
+func Complete(prompt string) (*Completion, error) {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return nil, i18n.Errorf("config.load_error", err)
+	}
+
+	provider, model := GetConfiguredProvider(config)
+	return CompleteWith(provider, model, prompt)
+}
+
+// loadConfigOrDefault carrega a configuração para uso interno; sem ela, usa os valores padrão
+func loadConfigOrDefault() *commons.Config {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return &commons.Config{}
+	}
+	return config
+}
+
+// redactRequest mascara segredos e dados pessoais do prompt de sistema e das mensagens
+// antes que saiam da máquina. No modo estrito, o comando é interrompido em vez de mascarar.
+// O Redactor retornado, nil se nada foi mascarado, restaura os trechos originais na resposta.
+func redactRequest(config *commons.Config, request *Request) (*Request, *redact.Redactor, error) {
+	if config.RedactionMode == redact.ModeOff {
+		return request, nil, nil
+	}
+
+	redactor, err := redact.New(config.PIIPatterns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	redacted := *request
+	system, findings := redactor.Redact(request.System)
+	redacted.System = system
+	redacted.Messages = make([]Message, len(request.Messages))
+	for i, message := range request.Messages {
+		content, messageFindings := redactor.Redact(message.Content)
+		redacted.Messages[i] = Message{Role: message.Role, Content: content}
+		findings = append(findings, messageFindings...)
+	}
+	if len(findings) == 0 {
+		return request, nil, nil
+	}
+
+	if config.RedactionMode == redact.ModeStrict {
+		return nil, nil, errors.New(i18n.T("redact.strict_abort", redact.Summary(findings)))
+	}
+
+	output.Progress(i18n.T("redact.masked", redact.Summary(findings)))
+	return &redacted, redactor, nil
+}
+
+// restoreResponse devolve à resposta os trechos mascarados no envio. Em respostas estruturadas, os
+// marcadores só podem estar dentro de strings JSON, então os trechos são escapados como tal.
+func restoreResponse(redactor *redact.Redactor, request *Request, text string) string {
+	if redactor == nil {
+		return text
+	}
+
+	var escape func(string) string
+	if request.Schema != nil {
+		escape = func(original string) string {
+			encoded, _ := json.Marshal(original)
+			return string(encoded[1 : len(encoded)-1])
+		}
+	}
+	return redactor.Restore(text, escape)
+}
+
+// dryRunCompletion exibe o prompt que seria enviado, com a estimativa de tokens, e retorna
+// uma resposta de marcação para que o comando siga até os efeitos colaterais, que são evitados
+func dryRunCompletion(config *commons.Config, provider Provider, model string, prompt string) *Completion {
+	tokens := dryrun.EstimateTokens(prompt)
+	cost := EstimateCost(config, model, tokens, 0)
+	dryrun.AddPrompt(dryrun.Prompt{
+		Provider:         provider.GetName(),
+		Model:            model,
+		Text:             prompt,
+		EstimatedTokens:  tokens,
+		EstimatedCostUSD: cost,
+	})
+
+	output.Progress(i18n.T("dryrun.prompt_header", provider.GetName(), model, tokens, cost))
+	output.Progress(prompt)
+	output.Progress(i18n.T("dryrun.prompt_footer"))
+
+	return &Completion{
+		Text:     i18n.T("dryrun.placeholder"),
+		Provider: provider.GetName(),
+		Model:    model,
+		DryRun:   true,
+	}
+}
+
+// CompleteWith envia o prompt para um provedor e modelo específicos e registra a geração no histórico.
+// Segredos e dados pessoais são mascarados antes do envio, e respostas para o mesmo provedor,
+// modelo e prompt são reaproveitadas do cache enquanto válidas.
+func CompleteWith(provider Provider, model string, prompt string) (*Completion, error) {
+	return CompleteRequestWith(provider, model, NewRequest(prompt))
+}
+
+// CompleteRequest envia uma requisição com prompt de sistema, mensagens e parâmetros para o provedor configurado
+func CompleteRequest(request *Request) (*Completion, error) {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return nil, i18n.Errorf("config.load_error", err)
+	}
+
+	provider, model := GetConfiguredProvider(config)
+	return CompleteRequestWith(provider, model, request)
+}
+
+// CompleteRequestWith envia uma requisição para um provedor e modelo específicos,
+// com o mesmo tratamento de CompleteWith
+func CompleteRequestWith(provider Provider, model string, request *Request) (*Completion, error) {
+	return complete(provider, model, request, nil)
+}
+
+// CompleteJSON envia o prompt para o provedor configurado pedindo uma resposta JSON no formato
+// da struct apontada por target, que recebe a resposta decodificada
+func CompleteJSON(prompt string, target interface{}) (*Completion, error) {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return nil, i18n.Errorf("config.load_error", err)
+	}
+
+	provider, model := GetConfiguredProvider(config)
+	return CompleteJSONWith(provider, model, prompt, target)
+}
+
+// CompleteJSONWith envia o prompt para um provedor e modelo específicos pedindo uma resposta JSON
+// no formato de target. A resposta é validada contra o schema e, se inválida, pedida mais uma vez.
+// No modo --dry-run, target não é preenchido.
+func CompleteJSONWith(provider Provider, model string, prompt string, target interface{}) (*Completion, error) {
+	return CompleteJSONRequestWith(provider, model, NewRequest(prompt), target)
+}
+
+// CompleteJSONRequest envia uma requisição para o provedor configurado pedindo uma resposta JSON
+// no formato de target, permitindo continuar uma conversa com respostas estruturadas
+func CompleteJSONRequest(request *Request, target interface{}) (*Completion, error) {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return nil, i18n.Errorf("config.load_error", err)
+	}
+
+	provider, model := GetConfiguredProvider(config)
+	return CompleteJSONRequestWith(provider, model, request, target)
+}
+
+// CompleteJSONRequestWith envia uma requisição para um provedor e modelo específicos pedindo uma
+// resposta JSON no formato de target, com a mesma validação de CompleteJSONWith. Uma requisição que
+// já tem schema (como a repetida pelo history rerun) o mantém, e target recebe o JSON genérico.
+func CompleteJSONRequestWith(provider Provider, model string, request *Request, target interface{}) (*Completion, error) {
+	structured := *request
+	if structured.Schema == nil {
+		structured.Schema = SchemaFor(target)
+	}
+	request = &structured
+	validate := func(text string) error {
+		return decodeStructured(text, request.Schema, target)
+	}
+
+	completion, err := complete(provider, model, request, validate)
+	if err != nil || completion.DryRun {
+		return completion, err
+	}
+
+	// A nova tentativa continua a conversa, mostrando ao modelo a resposta inválida e o erro
+	if err := validate(completion.Text); err != nil {
+		output.Progress(i18n.T("ai.structured_retry", err))
+		retry := request.Reply(completion.Text, fmt.Sprintf(structuredRetryPrompt, err))
+		completion, err = complete(provider, model, retry, validate)
+		if err != nil {
+			return nil, err
+		}
+		if err := validate(completion.Text); err != nil {
+			return nil, i18n.Errorf("ai.structured_invalid", completion.Provider, err)
+		}
+	}
+
+	return completion, nil
+}
+
+// complete executa uma geração, livre ou estruturada conforme o schema da requisição. Respostas que
+// não passam na validação não são gravadas no cache nem aproveitadas a partir dele.
+func complete(provider Provider, model string, request *Request, validate func(string) error) (*Completion, error) {
+	if model == "" {
+		model = provider.GetDefaultModel()
+	}
+
+	// Com --show-context, nada é enviado: o comando apenas lista os arquivos reunidos
+	if policy.ShowContext() {
+		return nil, policy.Stop()
+	}
+	if denied := policy.Denied(); len(denied) > 0 {
+		output.Progress(i18n.T("policy.denied_summary", len(denied), strings.Join(denied, ", ")))
+	}
+
+	config := loadConfigOrDefault()
+	request, redactor, err := redactRequest(config, request)
+	if err != nil {
+		return nil, err
+	}
+
+	// O limite de tokens configurado vale para as requisições que não definem o seu
+	if request.MaxTokens == 0 && config.MaxTokens > 0 {
+		limited := *request
+		limited.MaxTokens = config.MaxTokens
+		request = &limited
+	}
+	prompt := request.Prompt()
+
+	// Com --dry-run, o prompt final é exibido no lugar da chamada ao provedor
+	if dryrun.IsEnabled() {
+		return dryRunCompletion(config, provider, model, prompt), nil
+	}
+
+	start := time.Now()
+	key := cache.Key(provider.GetName(), model, request.fingerprint())
+
+	completion := &Completion{
+		Provider: provider.GetName(),
+		Model:    model,
+	}
+
+	cached, ok := cache.Get(key)
+	if ok && validate != nil && validate(cached.Text) != nil {
+		ok = false
+	}
+
+	if ok {
+		completion.Text = cached.Text
+		completion.Cached = true
+		output.Progress(i18n.T("cache.hit"))
+	} else {
+		if err := checkBudget(config); err != nil {
+			return nil, err
+		}
+
+		text, err := provider.GetCompletions(request, model)
+		if err != nil {
+			return nil, err
+		}
+		completion.Text = text
+		recordUsage(config, provider, completion)
+
+		if validate == nil || validate(text) == nil {
+			err = cache.Put(key, &cache.Entry{Provider: completion.Provider, Model: model, Text: text})
+			if err != nil {
+				output.Progress(i18n.T("cache.write_warning", err))
+			}
+		}
+	}
+	completion.Duration = time.Since(start)
+
+	// O cache e o histórico guardam a resposta com os marcadores, como o prompt; quem usa a resposta
+	// recebe os trechos originais, para que nada gravado em disco fique com os marcadores
+	response := completion.Text
+	completion.Text = restoreResponse(redactor, request, response)
+
+	// Uma falha ao gravar o histórico não deve impedir o uso da resposta
+	requestData, _ := json.Marshal(request)
+	err = history.Record(&history.Entry{
+		Prompt:       prompt,
+		Request:      requestData,
+		Response:     response,
+		Provider:     completion.Provider,
+		Model:        completion.Model,
+		DurationMs:   completion.Duration.Milliseconds(),
+		Cached:       completion.Cached,
+		InputTokens:  completion.Usage.InputTokens,
+		OutputTokens: completion.Usage.OutputTokens,
+		CostUSD:      completion.Usage.CostUSD,
+		Files:        policy.Included(),
+		CreatedAt:    start,
+	})
+	if err != nil {
+		output.Progress(i18n.T("history.record_warning", err))
+	}
+
+	return completion, nil
+}
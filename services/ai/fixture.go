@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Modos do provedor de fixtures
+const (
+	FixtureRecord = "record"
+	FixtureReplay = "replay"
+)
+
+// Fixture representa um par de requisição e resposta gravado por FixtureProvider
+type Fixture struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Prompt   string `json:"prompt"`
+	Response string `json:"response"`
+	Usage    Usage  `json:"usage"`
+}
+
+// FixtureProvider implementa a interface Provider gravando as respostas de um provedor real
+// em arquivos (modo record) e reproduzindo-as depois sem acesso à rede (modo replay).
+// É configurado por GOJIRA_FIXTURES_MODE, GOJIRA_FIXTURES_DIR e GOJIRA_FIXTURES_PROVIDER.
+type FixtureProvider struct {
+	mode         string
+	dir          string
+	providerName string
+	lastUsage    Usage
+}
+
+// NewFixtureProvider cria uma nova instância do provedor de fixtures
+func NewFixtureProvider() Provider {
+	provider := &FixtureProvider{
+		mode:         strings.ToLower(os.Getenv("GOJIRA_FIXTURES_MODE")),
+		dir:          os.Getenv("GOJIRA_FIXTURES_DIR"),
+		providerName: strings.ToLower(os.Getenv("GOJIRA_FIXTURES_PROVIDER")),
+	}
+	if provider.mode == "" {
+		provider.mode = FixtureReplay
+	}
+	if provider.dir == "" {
+		provider.dir = filepath.Join("testdata", "fixtures")
+	}
+	if provider.providerName == "" {
+		provider.providerName = "openai"
+	}
+	return provider
+}
+
+// GetName retorna o nome do provedor
+func (p *FixtureProvider) GetName() string {
+	return "Fixture"
+}
+
+// fixtureCatalogs fornecem os modelos dos provedores gravados sem criá-los: no modo replay não há
+// chave de API, e criar o provedor real apenas geraria avisos
+var fixtureCatalogs = map[string]Provider{
+	"openai":    &OpenAIProvider{},
+	"anthropic": &AnthropicProvider{},
+	"mock":      &MockProvider{},
+}
+
+// GetAvailableModels retorna os modelos do provedor gravado
+func (p *FixtureProvider) GetAvailableModels() []string {
+	if catalog, exists := fixtureCatalogs[p.providerName]; exists {
+		return catalog.GetAvailableModels()
+	}
+	return []string{}
+}
+
+// GetDefaultModel retorna o modelo padrão do provedor gravado
+func (p *FixtureProvider) GetDefaultModel() string {
+	if catalog, exists := fixtureCatalogs[p.providerName]; exists {
+		return catalog.GetDefaultModel()
+	}
+	return ""
+}
+
+// GetLastUsage implementa a interface UsageReporter.GetLastUsage
+func (p *FixtureProvider) GetLastUsage() Usage {
+	return p.lastUsage
+}
+
+// GetCompletions implementa a interface Provider.GetCompletions,
+// gravando ou reproduzindo a resposta conforme o modo configurado
+func (p *FixtureProvider) GetCompletions(request *Request, modelID string) (string, error) {
+	p.lastUsage = Usage{}
+	path := filepath.Join(p.dir, fixtureKey(p.providerName, modelID, request)+".json")
+
+	switch p.mode {
+	case FixtureReplay:
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			return "", errors.New(i18n.T("fixture.not_found", path))
+		}
+		if err != nil {
+			return "", i18n.Errorf("fixture.read_error", path, err)
+		}
+
+		var fixture Fixture
+		if err := json.Unmarshal(data, &fixture); err != nil {
+			return "", i18n.Errorf("fixture.read_error", path, err)
+		}
+		p.lastUsage = fixture.Usage
+		return fixture.Response, nil
+
+	case FixtureRecord:
+		provider, err := p.recorded()
+		if err != nil {
+			return "", err
+		}
+
+		response, err := provider.GetCompletions(request, modelID)
+		if err != nil {
+			return "", err
+		}
+		if reporter, ok := provider.(UsageReporter); ok {
+			p.lastUsage = reporter.GetLastUsage()
+		}
+
+		fixture := &Fixture{
+			Provider: p.providerName,
+			Model:    modelID,
+			Prompt:   request.Prompt(),
+			Response: response,
+			Usage:    p.lastUsage,
+		}
+		data, err := json.MarshalIndent(fixture, "", "  ")
+		if err != nil {
+			return "", i18n.Errorf("fixture.write_error", path, err)
+		}
+		if err := os.MkdirAll(p.dir, 0755); err != nil {
+			return "", i18n.Errorf("fixture.write_error", path, err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return "", i18n.Errorf("fixture.write_error", path, err)
+		}
+		return response, nil
+
+	default:
+		return "", errors.New(i18n.T("fixture.invalid_mode", p.mode))
+	}
+}
+
+// recorded retorna o provedor real cujas respostas são gravadas
+func (p *FixtureProvider) recorded() (Provider, error) {
+	if p.providerName == "fixture" {
+		return nil, errors.New(i18n.T("fixture.invalid_provider", p.providerName))
+	}
+	provider, exists := GetProvider(p.providerName)
+	if !exists {
+		return nil, errors.New(i18n.T("fixture.invalid_provider", p.providerName))
+	}
+	return provider, nil
+}
+
+// fixtureKey identifica a fixture pelo provedor, modelo e requisição
+func fixtureKey(provider, model string, request *Request) string {
+	hash := sha256.Sum256([]byte(provider + "\x00" + model + "\x00" + request.fingerprint()))
+	return hex.EncodeToString(hash[:])
+}
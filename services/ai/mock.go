@@ -0,0 +1,142 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"gojira/utils/dryrun"
+	"gojira/utils/i18n"
+	"os"
+	"regexp"
+)
+
+// MockRule representa uma resposta roteirizada do provedor mock.
+// A primeira regra cuja expressão casar com o prompt é usada; sem expressão, a regra casa com qualquer prompt.
+type MockRule struct {
+	Match    string `json:"match,omitempty"`
+	Response string `json:"response"`
+}
+
+// MockProvider implementa a interface Provider com respostas determinísticas, sem acesso à rede.
+// A resposta vem de GOJIRA_MOCK_RESPONSE, das regras do arquivo em GOJIRA_MOCK_SCRIPT
+// ou, na falta de ambos, de um texto fixo derivado do prompt.
+type MockProvider struct {
+	response   string
+	scriptPath string
+	lastUsage  Usage
+}
+
+// NewMockProvider cria uma nova instância do provedor mock
+func NewMockProvider() Provider {
+	return &MockProvider{
+		response:   os.Getenv("GOJIRA_MOCK_RESPONSE"),
+		scriptPath: os.Getenv("GOJIRA_MOCK_SCRIPT"),
+	}
+}
+
+// GetName retorna o nome do provedor
+func (p *MockProvider) GetName() string {
+	return "Mock"
+}
+
+// GetAvailableModels retorna a lista de modelos disponíveis
+func (p *MockProvider) GetAvailableModels() []string {
+	return []string{"mock-1"}
+}
+
+// GetDefaultModel retorna o modelo padrão
+func (p *MockProvider) GetDefaultModel() string {
+	return "mock-1"
+}
+
+// GetLastUsage implementa a interface UsageReporter.GetLastUsage
+func (p *MockProvider) GetLastUsage() Usage {
+	return p.lastUsage
+}
+
+// GetCompletions implementa a interface Provider.GetCompletions. As regras do roteiro são
+// comparadas com o texto da requisição inteira, incluindo o prompt de sistema e as mensagens anteriores.
+// Sem resposta fixa ou roteiro, uma requisição estruturada recebe um exemplo válido gerado a partir do schema.
+func (p *MockProvider) GetCompletions(request *Request, modelID string) (string, error) {
+	prompt := request.Prompt()
+
+	var response string
+	var err error
+	if request.Schema != nil && p.response == "" && p.scriptPath == "" {
+		var data []byte
+		data, err = json.Marshal(sampleOf(request.Schema.Definition))
+		response = string(data)
+	} else {
+		response, err = p.respond(prompt)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	p.lastUsage = Usage{
+		InputTokens:  dryrun.EstimateTokens(prompt),
+		OutputTokens: dryrun.EstimateTokens(response),
+	}
+	return response, nil
+}
+
+// respond escolhe a resposta para o prompt
+func (p *MockProvider) respond(prompt string) (string, error) {
+	if p.scriptPath != "" {
+		data, err := os.ReadFile(p.scriptPath)
+		if err != nil {
+			return "", i18n.Errorf("mock.script_read_error", p.scriptPath, err)
+		}
+
+		var rules []MockRule
+		if err := json.Unmarshal(data, &rules); err != nil {
+			return "", i18n.Errorf("mock.script_parse_error", p.scriptPath, err)
+		}
+
+		for _, rule := range rules {
+			if rule.Match == "" {
+				return rule.Response, nil
+			}
+			matched, err := regexp.MatchString(rule.Match, prompt)
+			if err != nil {
+				return "", i18n.Errorf("mock.invalid_match", rule.Match, err)
+			}
+			if matched {
+				return rule.Response, nil
+			}
+		}
+		return "", i18n.Errorf("mock.no_rule", p.scriptPath)
+	}
+
+	if p.response != "" {
+		return p.response, nil
+	}
+
+	hash := sha256.Sum256([]byte(prompt))
+	return "mock response " + hex.EncodeToString(hash[:4]), nil
+}
+
+// sampleOf gera um valor de exemplo determinístico que segue o schema
+func sampleOf(definition map[string]interface{}) interface{} {
+	switch definition["type"] {
+	case "string":
+		return "mock"
+	case "boolean":
+		return false
+	case "integer", "number":
+		return 0
+	case "array":
+		items, _ := definition["items"].(map[string]interface{})
+		return []interface{}{sampleOf(items)}
+	case "object":
+		object := map[string]interface{}{}
+		properties, _ := definition["properties"].(map[string]interface{})
+		for name, property := range properties {
+			propertyDefinition, _ := property.(map[string]interface{})
+			object[name] = sampleOf(propertyDefinition)
+		}
+		return object
+	default:
+		return nil
+	}
+}
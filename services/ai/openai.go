@@ -4,16 +4,20 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"gojira/utils/commons"
+	"gojira/utils/i18n"
 	"io"
 	"net/http"
 	"time"
 )
 
+// openAIMaxTokens é o limite de tokens das respostas quando nem a requisição nem a configuração o definem
+const openAIMaxTokens = 16383
+
 // OpenAIProvider implementa a interface Provider para a OpenAI
 type OpenAIProvider struct {
-	apiKey string
+	apiKey    string
+	lastUsage Usage
 }
 
 // NewOpenAIProvider cria uma nova instância do provedor OpenAI
@@ -43,10 +47,18 @@ func (p *OpenAIProvider) GetDefaultModel() string {
 	return "gpt-4o"
 }
 
-// GetCompletions implementa a interface Provider.GetCompletions
-func (p *OpenAIProvider) GetCompletions(prompt string, modelID string) (string, error) {
+// GetLastUsage implementa a interface UsageReporter.GetLastUsage
+func (p *OpenAIProvider) GetLastUsage() Usage {
+	return p.lastUsage
+}
+
+// GetCompletions implementa a interface Provider.GetCompletions.
+// Respostas estruturadas usam o modo JSON schema.
+func (p *OpenAIProvider) GetCompletions(request *Request, modelID string) (string, error) {
+	p.lastUsage = Usage{}
+
 	if p.apiKey == "" {
-		return "", errors.New("OPENAI_API_KEY não fornecido")
+		return "", errors.New(i18n.T("ai.missing_key", "OPENAI_API_KEY"))
 	}
 
 	if modelID == "" {
@@ -54,10 +66,35 @@ func (p *OpenAIProvider) GetCompletions(prompt string, modelID string) (string,
 	}
 
 	url := "https://api.openai.com/v1/chat/completions"
+	// O prompt de sistema é a primeira mensagem da conversa
+	messages := []map[string]string{}
+	if request.System != "" {
+		messages = append(messages, map[string]string{"role": "system", "content": request.System})
+	}
+	for _, message := range request.Messages {
+		messages = append(messages, map[string]string{"role": message.Role, "content": message.Content})
+	}
+
 	body := map[string]interface{}{
 		"model":      modelID,
-		"messages":   []map[string]string{{"role": "user", "content": prompt}},
-		"max_tokens": 16383,
+		"messages":   messages,
+		"max_tokens": request.GetMaxTokens(openAIMaxTokens),
+	}
+	if request.Temperature != nil {
+		body["temperature"] = *request.Temperature
+	}
+	if len(request.Stop) > 0 {
+		body["stop"] = request.Stop
+	}
+	if request.Schema != nil {
+		body["response_format"] = map[string]interface{}{
+			"type": "json_schema",
+			"json_schema": map[string]interface{}{
+				"name":   request.Schema.Name,
+				"schema": request.Schema.Definition,
+				"strict": true,
+			},
+		}
 	}
 
 	jsonBody, err := json.Marshal(body)
@@ -84,7 +121,7 @@ func (p *OpenAIProvider) GetCompletions(prompt string, modelID string) (string,
 
 	formattedBody, err := json.MarshalIndent(body, "", "  ")
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("falha na chamada à API (%d): %s", resp.StatusCode, string(formattedBody))
+		return "", errors.New(i18n.T("ai.api_error", p.GetName(), resp.StatusCode, string(formattedBody)))
 	}
 
 	var result map[string]interface{}
@@ -92,10 +129,17 @@ func (p *OpenAIProvider) GetCompletions(prompt string, modelID string) (string,
 		return "", err
 	}
 
+	if usage, ok := result["usage"].(map[string]interface{}); ok {
+		p.lastUsage = Usage{
+			InputTokens:  numberToInt(usage["prompt_tokens"]),
+			OutputTokens: numberToInt(usage["completion_tokens"]),
+		}
+	}
+
 	if choices, ok := result["choices"].([]interface{}); ok && len(choices) > 0 {
 		firstChoice := choices[0].(map[string]interface{})
 		return firstChoice["message"].(map[string]interface{})["content"].(string), nil
 	}
 
-	return "", errors.New("resposta inesperada da API OpenAI")
+	return "", errors.New(i18n.T("ai.unexpected_response", p.GetName()))
 }
@@ -11,14 +11,17 @@ type Provider interface {
 	// GetDefaultModel retorna o modelo padrão a ser usado
 	GetDefaultModel() string
 
-	// GetCompletions envia um prompt para o provedor de IA e retorna a resposta
-	GetCompletions(prompt string, modelID string) (string, error)
+	// GetCompletions envia a requisição para o provedor de IA e retorna a resposta.
+	// Se a requisição tiver um schema, a resposta é o JSON que o segue.
+	GetCompletions(request *Request, modelID string) (string, error)
 }
 
 // ProviderFactory é um mapa de funções que criam instâncias de provedores de IA
 var ProviderFactory = map[string]func() Provider{
 	"openai":    NewOpenAIProvider,
 	"anthropic": NewAnthropicProvider,
+	"mock":      NewMockProvider,
+	"fixture":   NewFixtureProvider,
 }
 
 // GetProvider retorna uma instância do provedor especificado
@@ -0,0 +1,96 @@
+package ai
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Papéis das mensagens de uma conversa
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// fallbackMaxTokens é o limite de tokens da resposta dos provedores sem um limite próprio
+const fallbackMaxTokens = 4096
+
+// Message representa uma mensagem de uma conversa com o provedor de IA
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Request representa uma requisição a um provedor de IA: o prompt de sistema, o histórico
+// de mensagens e os parâmetros da geração. Com Schema, a resposta deve ser um JSON que o siga.
+type Request struct {
+	System      string    `json:"system,omitempty"`
+	Messages    []Message `json:"messages"`
+	Temperature *float64  `json:"temperature,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+	Stop        []string  `json:"stop,omitempty"`
+	Schema      *Schema   `json:"schema,omitempty"`
+}
+
+// NewRequest cria uma requisição com uma única mensagem do usuário
+func NewRequest(prompt string) *Request {
+	return &Request{Messages: []Message{{Role: RoleUser, Content: prompt}}}
+}
+
+// Prompt retorna o texto da requisição, usado no histórico, no --dry-run e pelos provedores de teste.
+// Uma requisição com uma única mensagem do usuário e sem prompt de sistema retorna o próprio prompt.
+func (r *Request) Prompt() string {
+	if r.System == "" && len(r.Messages) == 1 && r.Messages[0].Role == RoleUser {
+		return r.Messages[0].Content
+	}
+
+	parts := []string{}
+	if r.System != "" {
+		parts = append(parts, "[system]\n"+r.System)
+	}
+	for _, message := range r.Messages {
+		parts = append(parts, "["+message.Role+"]\n"+message.Content)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// GetMaxTokens retorna o limite de tokens da resposta: o da requisição ou, se ela não o definir,
+// o padrão do provedor
+func (r *Request) GetMaxTokens(providerDefault int) int {
+	if r.MaxTokens > 0 {
+		return r.MaxTokens
+	}
+	return providerDefault
+}
+
+// DefaultMaxTokens retorna o limite de tokens da resposta do provedor quando nem a requisição nem a
+// configuração o definem. Um provedor desconhecido é substituído pelo padrão, a OpenAI.
+func DefaultMaxTokens(providerName string) int {
+	switch providerName {
+	case "anthropic":
+		return anthropicMaxTokens
+	case "mock", "fixture":
+		return fallbackMaxTokens
+	}
+	return openAIMaxTokens
+}
+
+// Reply retorna uma cópia da requisição com a resposta do assistente e uma nova mensagem do usuário
+func (r *Request) Reply(response string, prompt string) *Request {
+	reply := *r
+	reply.Messages = append(append([]Message{}, r.Messages...),
+		Message{Role: RoleAssistant, Content: response},
+		Message{Role: RoleUser, Content: prompt},
+	)
+	return &reply
+}
+
+// fingerprint identifica a requisição completa, usado para compor chaves de cache e fixtures
+func (r *Request) fingerprint() string {
+	if r.Schema == nil && r.System == "" && r.Temperature == nil && r.MaxTokens == 0 &&
+		len(r.Stop) == 0 && len(r.Messages) == 1 && r.Messages[0].Role == RoleUser {
+		return r.Messages[0].Content
+	}
+
+	data, _ := json.Marshal(r)
+	return string(data)
+}
@@ -0,0 +1,187 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"gojira/utils/i18n"
+	"reflect"
+	"strings"
+)
+
+// Schema representa o JSON Schema que a resposta estruturada de um provedor deve seguir
+type Schema struct {
+	Name       string                 `json:"name"`
+	Definition map[string]interface{} `json:"definition"`
+}
+
+// structuredRetryPrompt é enviado como nova mensagem quando a primeira resposta estruturada é inválida
+const structuredRetryPrompt = "Your previous response was not valid for the requested JSON schema (%v). " +
+	"Respond again with only a JSON object that matches the schema exactly."
+
+// SchemaFor gera o JSON Schema de uma struct Go a partir das tags json.
+// A tag description é usada como descrição do campo.
+func SchemaFor(v interface{}) *Schema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return &Schema{Name: t.Name(), Definition: schemaOf(t)}
+}
+
+// schemaOf converte um tipo Go em JSON Schema. Todos os campos são obrigatórios
+// e propriedades extras são proibidas, como exige o modo estrito da OpenAI.
+func schemaOf(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem())
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaOf(t.Elem())}
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonFieldName(field)
+			if name == "" {
+				continue
+			}
+			property := schemaOf(field.Type)
+			if description := field.Tag.Get("description"); description != "" {
+				property["description"] = description
+			}
+			properties[name] = property
+			required = append(required, name)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// jsonFieldName retorna o nome do campo no JSON, ou vazio se ele não é serializado
+func jsonFieldName(field reflect.StructField) string {
+	if field.PkgPath != "" {
+		return ""
+	}
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// decodeStructured valida a resposta contra o schema e a decodifica no destino
+func decodeStructured(text string, schema *Schema, target interface{}) error {
+	text = strings.TrimSpace(text)
+
+	// Alguns modelos envolvem o JSON em um bloco de código mesmo no modo estruturado
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```json")
+		text = strings.TrimPrefix(text, "```")
+		text = strings.TrimSuffix(strings.TrimSpace(text), "```")
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(text), &value); err != nil {
+		return err
+	}
+	if err := validateValue(value, schema.Definition, "$"); err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(text), target)
+}
+
+// validateValue verifica recursivamente se um valor decodificado segue o schema
+func validateValue(value interface{}, definition map[string]interface{}, path string) error {
+	switch definition["type"] {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return typeError(path, "string")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return typeError(path, "boolean")
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return typeError(path, "integer")
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return typeError(path, "number")
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return typeError(path, "array")
+		}
+		itemDefinition, _ := definition["items"].(map[string]interface{})
+		for i, item := range items {
+			if err := validateValue(item, itemDefinition, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return typeError(path, "object")
+		}
+		properties, _ := definition["properties"].(map[string]interface{})
+		for _, name := range requiredFields(definition) {
+			if _, exists := object[name]; !exists {
+				return i18n.Errorf("ai.structured_missing_field", path+"."+name)
+			}
+		}
+		for name, fieldValue := range object {
+			property, exists := properties[name].(map[string]interface{})
+			if !exists {
+				return i18n.Errorf("ai.structured_unknown_field", path+"."+name)
+			}
+			if err := validateValue(fieldValue, property, path+"."+name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requiredFields retorna os campos obrigatórios de um objeto, seja o schema gerado por SchemaFor
+// ([]string) ou decodificado de JSON, como o guardado no histórico ([]interface{})
+func requiredFields(definition map[string]interface{}) []string {
+	switch required := definition["required"].(type) {
+	case []string:
+		return required
+	case []interface{}:
+		names := make([]string, 0, len(required))
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// typeError cria o erro de tipo inválido para um caminho da resposta
+func typeError(path, expected string) error {
+	return i18n.Errorf("ai.structured_wrong_type", path, expected)
+}
@@ -0,0 +1,40 @@
+package ai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeStructuredEnforcesRequiredFields(t *testing.T) {
+	type response struct {
+		Code  string   `json:"code"`
+		Notes []string `json:"notes"`
+	}
+
+	// O schema guardado no histórico volta do JSON com []interface{} no lugar de []string
+	built := SchemaFor(&response{})
+	data, err := json.Marshal(built)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Schema
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, schema := range map[string]*Schema{"gerado": built, "decodificado": &decoded} {
+		var target response
+		if err := decodeStructured(`{"code": "x", "notes": []}`, schema, &target); err != nil || target.Code != "x" {
+			t.Errorf("%s: resposta válida rejeitada: %v", name, err)
+		}
+		if err := decodeStructured(`{"code": "x"}`, schema, &target); err == nil {
+			t.Errorf("%s: campo obrigatório ausente aceito", name)
+		}
+		if err := decodeStructured(`{"code": "x", "notes": [], "extra": 1}`, schema, &target); err == nil {
+			t.Errorf("%s: campo desconhecido aceito", name)
+		}
+		if err := decodeStructured(`{"code": 1, "notes": []}`, schema, &target); err == nil {
+			t.Errorf("%s: tipo inválido aceito", name)
+		}
+	}
+}
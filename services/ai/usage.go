@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"errors"
+	"gojira/services/history"
+	"gojira/services/usage"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"time"
+)
+
+// Usage representa o consumo de tokens e o custo estimado de uma geração
+type Usage struct {
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// UsageReporter é implementado pelos provedores que informam o consumo de tokens da última chamada
+type UsageReporter interface {
+	// GetLastUsage retorna os tokens consumidos pela última chamada a GetCompletions
+	GetLastUsage() Usage
+}
+
+// DefaultPrices é a tabela de preços padrão, em dólares por milhão de tokens.
+// Pode ser sobreposta por modelo com a opção prices da configuração.
+var DefaultPrices = map[string]commons.ModelPrice{
+	"gpt-4o":                     {Input: 2.50, Output: 10.00},
+	"gpt-4-turbo":                {Input: 10.00, Output: 30.00},
+	"gpt-4":                      {Input: 30.00, Output: 60.00},
+	"gpt-3.5-turbo":              {Input: 0.50, Output: 1.50},
+	"claude-3-opus-20240229":     {Input: 15.00, Output: 75.00},
+	"claude-3-sonnet-20240229":   {Input: 3.00, Output: 15.00},
+	"claude-3-haiku-20240307":    {Input: 0.25, Output: 1.25},
+	"claude-3-5-sonnet-20240620": {Input: 3.00, Output: 15.00},
+}
+
+// GetPrice retorna o preço de um modelo, dando prioridade à configuração
+func GetPrice(config *commons.Config, model string) (commons.ModelPrice, bool) {
+	if price, exists := config.Prices[model]; exists {
+		return price, true
+	}
+	price, exists := DefaultPrices[model]
+	return price, exists
+}
+
+// EstimateCost calcula o custo de uma geração a partir da tabela de preços
+func EstimateCost(config *commons.Config, model string, inputTokens, outputTokens int) float64 {
+	price, exists := GetPrice(config, model)
+	if !exists {
+		return 0
+	}
+	return (float64(inputTokens)*price.Input + float64(outputTokens)*price.Output) / 1000000
+}
+
+// checkBudget verifica o orçamento mensal antes de uma chamada ao provedor,
+// avisando ou bloqueando conforme a ação configurada
+func checkBudget(config *commons.Config) error {
+	if config.MonthlyBudgetUSD <= 0 {
+		return nil
+	}
+
+	spent, err := usage.MonthTotal(time.Now())
+	if err != nil {
+		output.Progress(i18n.T("usage.budget_check_warning", err))
+		return nil
+	}
+	if spent < config.MonthlyBudgetUSD {
+		return nil
+	}
+
+	if config.BudgetAction == usage.BudgetBlock {
+		return errors.New(i18n.T("usage.budget_exceeded", spent, config.MonthlyBudgetUSD))
+	}
+	output.Progress(i18n.T("usage.budget_warning", spent, config.MonthlyBudgetUSD))
+	return nil
+}
+
+// recordUsage calcula o custo da geração e registra o consumo
+func recordUsage(config *commons.Config, provider Provider, completion *Completion) {
+	reporter, ok := provider.(UsageReporter)
+	if !ok {
+		return
+	}
+
+	completion.Usage = reporter.GetLastUsage()
+	completion.Usage.CostUSD = EstimateCost(config, completion.Model, completion.Usage.InputTokens, completion.Usage.OutputTokens)
+	output.Progress(i18n.T("usage.summary", completion.Usage.InputTokens, completion.Usage.OutputTokens, completion.Usage.CostUSD))
+
+	// Uma falha ao registrar o consumo não deve impedir o uso da resposta
+	err := usage.Add(&usage.Record{
+		Command:      history.CurrentCommand(),
+		Provider:     completion.Provider,
+		Model:        completion.Model,
+		InputTokens:  completion.Usage.InputTokens,
+		OutputTokens: completion.Usage.OutputTokens,
+		CostUSD:      completion.Usage.CostUSD,
+	})
+	if err != nil {
+		output.Progress(i18n.T("usage.record_warning", err))
+	}
+}
+
+// numberToInt converte um número decodificado de JSON em int
+func numberToInt(value interface{}) int {
+	if number, ok := value.(float64); ok {
+		return int(number)
+	}
+	return 0
+}
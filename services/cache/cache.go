@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTTL é o tempo de vida padrão de uma resposta em cache
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultMaxSize é o tamanho máximo padrão do cache em disco
+	DefaultMaxSize = 50 * 1024 * 1024
+)
+
+// Entry representa uma resposta armazenada em cache
+type Entry struct {
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// enabled indica se o cache deve ser usado na execução atual
+var enabled = true
+
+// SetEnabled ativa ou desativa o cache para a execução atual
+func SetEnabled(value bool) {
+	enabled = value
+}
+
+// IsEnabled indica se o cache está ativo para a execução atual
+func IsEnabled() bool {
+	return enabled
+}
+
+// GetCacheDir retorna o diretório onde as respostas são armazenadas
+func GetCacheDir() string {
+	return filepath.Join(commons.GetDataDir(), "cache")
+}
+
+// Key calcula a chave do cache a partir do provedor, modelo, prompt e parâmetros da geração
+func Key(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+// Get retorna a resposta em cache para a chave, se existir e não estiver expirada
+func Get(key string) (*Entry, bool) {
+	if !enabled {
+		return nil, false
+	}
+
+	path := filepath.Join(GetCacheDir(), key+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	ttl, _ := limits()
+	if time.Since(entry.CreatedAt) > ttl {
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// Put armazena uma resposta no cache e remove as mais antigas se o tamanho máximo for excedido.
+// A escrita usa um arquivo temporário e rename para ser segura entre execuções simultâneas.
+func Put(key string, entry *Entry) error {
+	if !enabled {
+		return nil
+	}
+
+	dir := GetCacheDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return i18n.Errorf("cache.dir_error", err)
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return i18n.Errorf("cache.write_error", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, key+".*.tmp")
+	if err != nil {
+		return i18n.Errorf("cache.write_error", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return i18n.Errorf("cache.write_error", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return i18n.Errorf("cache.write_error", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, key+".json")); err != nil {
+		_ = os.Remove(tmp.Name())
+		return i18n.Errorf("cache.write_error", err)
+	}
+
+	_, maxSize := limits()
+	return evict(maxSize)
+}
+
+// Clear remove todas as respostas do cache, retornando quantas foram removidas
+func Clear() (int, error) {
+	files, err := os.ReadDir(GetCacheDir())
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, i18n.Errorf("cache.read_error", err)
+	}
+
+	removed := 0
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if err := os.Remove(filepath.Join(GetCacheDir(), file.Name())); err != nil && !os.IsNotExist(err) {
+			return removed, i18n.Errorf("cache.remove_error", err)
+		}
+		if filepath.Ext(file.Name()) == ".json" {
+			removed++
+		}
+	}
+
+	return removed, nil
+}
+
+// evict remove as respostas mais antigas até o cache ficar abaixo do tamanho máximo
+func evict(maxSize int64) error {
+	files, err := os.ReadDir(GetCacheDir())
+	if err != nil {
+		return i18n.Errorf("cache.read_error", err)
+	}
+
+	var infos []os.FileInfo
+	var total int64
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+		total += info.Size()
+	}
+
+	if total <= maxSize {
+		return nil
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+
+	for _, info := range infos {
+		if total <= maxSize {
+			break
+		}
+		// Outra execução pode já ter removido o arquivo
+		if err := os.Remove(filepath.Join(GetCacheDir(), info.Name())); err != nil && !os.IsNotExist(err) {
+			return i18n.Errorf("cache.remove_error", err)
+		}
+		total -= info.Size()
+	}
+
+	return nil
+}
+
+// limits retorna o tempo de vida e o tamanho máximo do cache conforme a configuração
+func limits() (time.Duration, int64) {
+	ttl := DefaultTTL
+	var maxSize int64 = DefaultMaxSize
+
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return ttl, maxSize
+	}
+	if config.CacheTTLHours > 0 {
+		ttl = time.Duration(config.CacheTTLHours) * time.Hour
+	}
+	if config.CacheMaxSizeMB > 0 {
+		maxSize = int64(config.CacheMaxSizeMB) * 1024 * 1024
+	}
+	return ttl, maxSize
+}
@@ -0,0 +1,137 @@
+package codehost
+
+import (
+	"fmt"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"net/url"
+	"strings"
+)
+
+// Bitbucket cria e atualiza pull requests pela API REST 1.0 do Bitbucket Server (Data Center)
+type Bitbucket struct {
+	client  *client
+	project string
+	repo    string
+}
+
+// bitbucketRef representa uma branch de origem ou destino do pull request
+type bitbucketRef struct {
+	ID         string `json:"id"`
+	Repository struct {
+		Slug    string `json:"slug"`
+		Project struct {
+			Key string `json:"key"`
+		} `json:"project"`
+	} `json:"repository"`
+}
+
+// bitbucketReviewer representa um revisor do pull request
+type bitbucketReviewer struct {
+	User struct {
+		Name string `json:"name"`
+	} `json:"user"`
+}
+
+// bitbucketPull representa um pull request na resposta da API
+type bitbucketPull struct {
+	ID        int                 `json:"id"`
+	Version   int                 `json:"version"`
+	Reviewers []bitbucketReviewer `json:"reviewers"`
+	Links     struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// NewBitbucket cria um cliente para o repositório na instância informada. Em remotos HTTP, o
+// caminho começa por scm/, que não faz parte da chave do projeto.
+func NewBitbucket(baseURL, token, project, repo string) *Bitbucket {
+	return &Bitbucket{
+		client:  newClient("Bitbucket", baseURL+"/rest/api/1.0", map[string]string{"Authorization": "Bearer " + token}),
+		project: strings.TrimPrefix(project, "scm/"),
+		repo:    repo,
+	}
+}
+
+// Name retorna o nome da plataforma
+func (b *Bitbucket) Name() string {
+	return "Bitbucket"
+}
+
+// CreateOrUpdate cria o pull request da branch ou atualiza o que já estiver aberto. O rascunho usa
+// o campo draft, disponível a partir do Bitbucket 8.18; labels, responsáveis e milestones não
+// existem no Bitbucket Server e são ignorados com um aviso.
+func (b *Bitbucket) CreateOrUpdate(pr *PullRequest) (*PullRequestResult, error) {
+	if len(pr.Labels) > 0 || len(pr.Assignees) > 0 || pr.Milestone != "" {
+		output.Progress(i18n.T("codehost.bitbucket_unsupported"))
+	}
+
+	repoPath := fmt.Sprintf("/projects/%s/repos/%s", url.PathEscape(b.project), url.PathEscape(b.repo))
+
+	query := url.Values{"state": {"OPEN"}, "direction": {"OUTGOING"}, "at": {"refs/heads/" + pr.Head}}
+	var page struct {
+		Values []bitbucketPull `json:"values"`
+	}
+	if err := b.client.do("GET", repoPath+"/pull-requests?"+query.Encode(), nil, &page); err != nil {
+		return nil, err
+	}
+
+	// Os revisores existentes são mantidos, já que a atualização substitui a lista inteira
+	reviewers := []bitbucketReviewer{}
+	seen := map[string]bool{}
+	if len(page.Values) > 0 {
+		for _, reviewer := range page.Values[0].Reviewers {
+			seen[reviewer.User.Name] = true
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+	for _, name := range pr.Reviewers {
+		if !seen[name] {
+			seen[name] = true
+			var reviewer bitbucketReviewer
+			reviewer.User.Name = name
+			reviewers = append(reviewers, reviewer)
+		}
+	}
+
+	body := map[string]interface{}{"title": pr.Title, "description": pr.Body, "reviewers": reviewers}
+	if pr.Base != "" {
+		body["toRef"] = b.ref(pr.Base)
+	}
+
+	var pull bitbucketPull
+	result := &PullRequestResult{}
+	if len(page.Values) > 0 {
+		existing := page.Values[0]
+		body["version"] = existing.Version
+		if err := b.client.do("PUT", fmt.Sprintf("%s/pull-requests/%d", repoPath, existing.ID), body, &pull); err != nil {
+			return nil, err
+		}
+		result.Updated = true
+	} else {
+		body["fromRef"] = b.ref(pr.Head)
+		if pr.Draft {
+			body["draft"] = true
+		}
+		if err := b.client.do("POST", repoPath+"/pull-requests", body, &pull); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Number = pull.ID
+	if len(pull.Links.Self) > 0 {
+		result.URL = pull.Links.Self[0].Href
+	}
+	return result, nil
+}
+
+// ref monta a referência de uma branch no próprio repositório
+func (b *Bitbucket) ref(branch string) bitbucketRef {
+	var ref bitbucketRef
+	ref.ID = "refs/heads/" + branch
+	ref.Repository.Slug = b.repo
+	ref.Repository.Project.Key = b.project
+	return ref
+}
@@ -0,0 +1,276 @@
+package codehost
+
+import (
+	"bytes"
+	"encoding/json"
+	"gojira/utils/commons"
+	"gojira/utils/git"
+	"gojira/utils/i18n"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// Plataformas de hospedagem de código suportadas
+const (
+	GitHubKind    = "github"
+	GitLabKind    = "gitlab"
+	GiteaKind     = "gitea"
+	BitbucketKind = "bitbucket"
+)
+
+// tokenVariables associa cada plataforma às variáveis de ambiente que podem conter o token
+var tokenVariables = map[string][]string{
+	GitHubKind:    {"GITHUB_TOKEN", "GH_TOKEN"},
+	GitLabKind:    {"GITLAB_TOKEN"},
+	GiteaKind:     {"GITEA_TOKEN"},
+	BitbucketKind: {"BITBUCKET_TOKEN"},
+}
+
+// publicHosts são os hosts públicos de cada plataforma, que recebem o token sem configuração extra
+var publicHosts = map[string][]string{
+	GitHubKind: {"github.com"},
+	GitLabKind: {"gitlab.com"},
+	GiteaKind:  {"codeberg.org", "gitea.com"},
+}
+
+// CodeHost representa uma plataforma que recebe pull requests (merge requests no GitLab)
+type CodeHost interface {
+	// Name retorna o nome da plataforma
+	Name() string
+
+	// CreateOrUpdate cria o pull request da branch ou atualiza o que já estiver aberto para ela
+	CreateOrUpdate(pr *PullRequest) (*PullRequestResult, error)
+}
+
+// IsKind indica se o nome é de uma plataforma suportada
+func IsKind(kind string) bool {
+	_, exists := tokenVariables[kind]
+	return exists
+}
+
+// Detect identifica a plataforma do repositório remoto: a definida na configuração ou, na falta dela,
+// a deduzida pelo host. Retorna vazio quando o host não é reconhecido.
+func Detect(config *commons.Config, remote *git.Remote) string {
+	if config.CodeHost != "" {
+		return config.CodeHost
+	}
+
+	host := strings.ToLower(remote.Host)
+	switch {
+	case host == GitHubHost(config.GitHubAPIURL) || strings.Contains(host, "github"):
+		return GitHubKind
+	case strings.Contains(host, "gitlab"):
+		return GitLabKind
+	case strings.Contains(host, "gitea") || strings.Contains(host, "forgejo") || host == "codeberg.org":
+		return GiteaKind
+	case strings.Contains(host, "bitbucket") && host != "bitbucket.org":
+		// O bitbucket.org usa a API do Bitbucket Cloud, diferente da do Bitbucket Server
+		return BitbucketKind
+	}
+	return ""
+}
+
+// Token retorna o token da plataforma: o da configuração ou o das variáveis de ambiente. Como a
+// plataforma pode ter sido deduzida só pelo nome do host, o token é retornado apenas quando será
+// enviado a um destino confiável (veja Trusted); caso contrário, o comando recorre ao gh ou ao glab.
+func Token(config *commons.Config, kind string, remote *git.Remote) string {
+	if remote == nil || !Trusted(config, kind, remote) {
+		return ""
+	}
+	if kind == GitHubKind && config.GitHubToken != "" {
+		return config.GitHubToken
+	}
+	if kind != GitHubKind && config.CodeHostToken != "" {
+		return config.CodeHostToken
+	}
+	for _, variable := range tokenVariables[kind] {
+		if token := os.Getenv(variable); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// Trusted indica se o token da plataforma pode ser enviado à API do remoto: a do host público da
+// plataforma ou a URL definida na configuração (github_api_url ou code_host_url). Um host reconhecido
+// apenas por conter o nome da plataforma, como github-mirror.example.net ou um alias SSH como
+// github-work, não recebe o token.
+func Trusted(config *commons.Config, kind string, remote *git.Remote) bool {
+	if config.CodeHostURL != "" || (kind == GitHubKind && config.GitHubAPIURL != "") {
+		return true
+	}
+	host := strings.ToLower(remote.Host)
+	for _, public := range publicHosts[kind] {
+		if host == public {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenVariable retorna a principal variável de ambiente com o token da plataforma
+func TokenVariable(kind string) string {
+	if variables := tokenVariables[kind]; len(variables) > 0 {
+		return variables[0]
+	}
+	return ""
+}
+
+// New cria o cliente da plataforma para o repositório remoto. A URL da API vem da configuração
+// ou é deduzida do host do remoto.
+func New(config *commons.Config, kind, token string, remote *git.Remote) (CodeHost, error) {
+	baseURL := config.CodeHostURL
+	if baseURL == "" {
+		baseURL = "https://" + remote.Host
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+
+	switch kind {
+	case GitHubKind:
+		apiURL := config.GitHubAPIURL
+		if apiURL == "" && remote.Host != "github.com" {
+			apiURL = baseURL + "/api/v3"
+		}
+		return NewGitHub(apiURL, token, remote.Owner, remote.Name), nil
+	case GitLabKind:
+		return NewGitLab(baseURL, token, remote.Path()), nil
+	case GiteaKind:
+		return NewGitea(baseURL, token, remote.Owner, remote.Name), nil
+	case BitbucketKind:
+		return NewBitbucket(baseURL, token, remote.Owner, remote.Name), nil
+	}
+	return nil, i18n.Errorf("codehost.unknown", kind)
+}
+
+// PullRequest representa os dados de um pull request a ser criado ou atualizado. HeadOwner é o dono
+// do repositório da branch quando ela está em um fork; vazio, a branch está no próprio repositório.
+type PullRequest struct {
+	Title     string
+	Body      string
+	Head      string
+	HeadOwner string
+	Base      string
+	Draft     bool
+	Reviewers []string
+	Labels    []string
+	Assignees []string
+	Milestone string
+}
+
+// PullRequestResult representa o pull request criado ou atualizado na plataforma
+type PullRequestResult struct {
+	Number  int    `json:"number"`
+	URL     string `json:"url"`
+	Updated bool   `json:"updated"`
+}
+
+// draftTitle marca o título como rascunho com o prefixo da plataforma, sem repeti-lo
+func draftTitle(prefix, title string, draft bool) string {
+	if !draft || strings.HasPrefix(strings.ToLower(title), strings.ToLower(prefix)) {
+		return title
+	}
+	return prefix + title
+}
+
+// splitReviewers separa os revisores em usuários e times, informados como org/time
+func splitReviewers(reviewers []string) ([]string, []string) {
+	users := []string{}
+	teams := []string{}
+	for _, reviewer := range reviewers {
+		if _, team, isTeam := strings.Cut(reviewer, "/"); isTeam {
+			teams = append(teams, team)
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+	return users, teams
+}
+
+// client é um cliente HTTP para APIs REST em JSON, compartilhado pelas plataformas
+type client struct {
+	name    string
+	baseURL string
+	headers map[string]string
+	http    *http.Client
+}
+
+// newClient cria um cliente para a API na URL base, com os cabeçalhos enviados em toda requisição
+func newClient(name, baseURL string, headers map[string]string) *client {
+	return &client{
+		name:    name,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		headers: headers,
+		http:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// do envia a requisição com o corpo em JSON e decodifica a resposta em target, quando informado
+func (c *client) do(method, path string, body interface{}, target interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return i18n.Errorf("codehost.request_error", c.name, err)
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return i18n.Errorf("codehost.request_error", c.name, err)
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return i18n.Errorf("codehost.api_error", c.name, method, path, resp.StatusCode, errorMessage(data))
+	}
+
+	if target != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, target); err != nil {
+			return i18n.Errorf("codehost.invalid_response", c.name, err)
+		}
+	}
+	return nil
+}
+
+// errorMessage extrai a mensagem de erro do corpo da resposta, quando ele segue o formato usual das APIs
+func errorMessage(data []byte) string {
+	var payload struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(data, &payload) != nil || payload.Message == "" {
+		return strings.TrimSpace(string(data))
+	}
+
+	message := payload.Message
+	for _, detail := range payload.Errors {
+		if detail.Message != "" {
+			message += "; " + detail.Message
+		}
+	}
+	return message
+}
@@ -0,0 +1,49 @@
+package codehost
+
+import (
+	"gojira/utils/commons"
+	"gojira/utils/git"
+	"testing"
+)
+
+func TestTokenOnlyForTrustedHosts(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "github-env")
+	t.Setenv("GH_TOKEN", "")
+	t.Setenv("GITLAB_TOKEN", "gitlab-env")
+	t.Setenv("GITEA_TOKEN", "")
+	t.Setenv("BITBUCKET_TOKEN", "")
+
+	tests := []struct {
+		name   string
+		config commons.Config
+		kind   string
+		host   string
+		want   string
+	}{
+		{"github.com", commons.Config{GitHubToken: "github-config"}, GitHubKind, "github.com", "github-config"},
+		{"github.com pela variável", commons.Config{}, GitHubKind, "github.com", "github-env"},
+		{"espelho com github no nome", commons.Config{GitHubToken: "github-config"}, GitHubKind, "github-mirror.example.net", ""},
+		{"alias SSH", commons.Config{}, GitHubKind, "github-work", ""},
+		{"GitHub Enterprise configurado", commons.Config{GitHubToken: "github-config", GitHubAPIURL: "https://ghe.acme.com/api/v3"}, GitHubKind, "ghe.acme.com", "github-config"},
+		{"gitlab.com", commons.Config{CodeHostToken: "shared"}, GitLabKind, "gitlab.com", "shared"},
+		{"gitlab.com pela variável", commons.Config{}, GitLabKind, "gitlab.com", "gitlab-env"},
+		{"GitLab reconhecido pelo nome", commons.Config{CodeHostToken: "shared"}, GitLabKind, "gitlab.acme.com", ""},
+		{"GitLab com URL configurada", commons.Config{CodeHostToken: "shared", CodeHostURL: "https://gitlab.acme.com"}, GitLabKind, "gitlab.acme.com", "shared"},
+		{"Codeberg", commons.Config{CodeHostToken: "shared"}, GiteaKind, "codeberg.org", "shared"},
+		{"Gitea reconhecido pelo nome", commons.Config{CodeHostToken: "shared"}, GiteaKind, "gitea.acme.com", ""},
+		{"Bitbucket Server sem URL", commons.Config{CodeHostToken: "shared"}, BitbucketKind, "bitbucket.acme.com", ""},
+		{"Bitbucket Server com URL", commons.Config{CodeHostToken: "shared", CodeHostURL: "https://bitbucket.acme.com"}, BitbucketKind, "bitbucket.acme.com", "shared"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := test.config
+			if got := Token(&config, test.kind, &git.Remote{Host: test.host, Owner: "acme", Name: "app"}); got != test.want {
+				t.Errorf("Token(%s, %q) = %q, esperado %q", test.kind, test.host, got, test.want)
+			}
+		})
+	}
+
+	if got := Token(&commons.Config{GitHubToken: "github-config"}, GitHubKind, nil); got != "" {
+		t.Errorf("Token sem remoto = %q, esperado vazio", got)
+	}
+}
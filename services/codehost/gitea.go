@@ -0,0 +1,168 @@
+package codehost
+
+import (
+	"fmt"
+	"gojira/utils/i18n"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Gitea cria e atualiza pull requests pela API v1 do Gitea (e do Forgejo)
+type Gitea struct {
+	client *client
+	owner  string
+	repo   string
+}
+
+// giteaPull representa um pull request na resposta da API
+type giteaPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+// NewGitea cria um cliente para o repositório owner/repo na instância informada
+func NewGitea(baseURL, token, owner, repo string) *Gitea {
+	return &Gitea{
+		client: newClient("Gitea", baseURL+"/api/v1", map[string]string{"Authorization": "token " + token}),
+		owner:  owner,
+		repo:   repo,
+	}
+}
+
+// Name retorna o nome da plataforma
+func (g *Gitea) Name() string {
+	return "Gitea"
+}
+
+// CreateOrUpdate cria o pull request da branch ou atualiza o que já estiver aberto. O rascunho é
+// indicado pelo prefixo "WIP:" no título, reconhecido pelo Gitea.
+func (g *Gitea) CreateOrUpdate(pr *PullRequest) (*PullRequestResult, error) {
+	repoPath := fmt.Sprintf("/repos/%s/%s", url.PathEscape(g.owner), url.PathEscape(g.repo))
+
+	existing, err := g.findOpen(repoPath, pr.Head)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{"body": pr.Body}
+	if pr.Base != "" {
+		body["base"] = pr.Base
+	}
+	if len(pr.Assignees) > 0 {
+		body["assignees"] = pr.Assignees
+	}
+	if len(pr.Labels) > 0 {
+		ids, err := g.labelIDs(repoPath, pr.Labels)
+		if err != nil {
+			return nil, err
+		}
+		body["labels"] = ids
+	}
+	if pr.Milestone != "" {
+		id, err := g.milestoneID(repoPath, pr.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		body["milestone"] = id
+	}
+
+	var pull giteaPull
+	result := &PullRequestResult{}
+	if existing != nil {
+		wasDraft := strings.HasPrefix(strings.ToUpper(existing.Title), "WIP:")
+		body["title"] = draftTitle("WIP: ", pr.Title, pr.Draft || wasDraft)
+		if err := g.client.do("PATCH", fmt.Sprintf("%s/pulls/%d", repoPath, existing.Number), body, &pull); err != nil {
+			return nil, err
+		}
+		result.Updated = true
+	} else {
+		body["title"] = draftTitle("WIP: ", pr.Title, pr.Draft)
+		body["head"] = pr.Head
+		if err := g.client.do("POST", repoPath+"/pulls", body, &pull); err != nil {
+			return nil, err
+		}
+	}
+	result.Number = pull.Number
+	result.URL = pull.HTMLURL
+
+	if len(pr.Reviewers) > 0 {
+		users, teams := splitReviewers(pr.Reviewers)
+		reviewers := map[string]interface{}{"reviewers": users, "team_reviewers": teams}
+		if err := g.client.do("POST", fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoPath, pull.Number), reviewers, nil); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// findOpen retorna o pull request aberto a partir da branch, se houver
+func (g *Gitea) findOpen(repoPath, head string) (*giteaPull, error) {
+	for page := 1; ; page++ {
+		var pulls []giteaPull
+		path := fmt.Sprintf("%s/pulls?state=open&limit=50&page=%d", repoPath, page)
+		if err := g.client.do("GET", path, nil, &pulls); err != nil {
+			return nil, err
+		}
+		for i := range pulls {
+			if pulls[i].Head.Ref == head {
+				return &pulls[i], nil
+			}
+		}
+		if len(pulls) < 50 {
+			return nil, nil
+		}
+	}
+}
+
+// labelIDs resolve os nomes das labels para os IDs exigidos pela API
+func (g *Gitea) labelIDs(repoPath string, names []string) ([]int, error) {
+	var labels []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := g.client.do("GET", repoPath+"/labels?limit=100", nil, &labels); err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for _, name := range names {
+		found := false
+		for _, label := range labels {
+			if strings.EqualFold(label.Name, name) {
+				ids = append(ids, label.ID)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, i18n.Errorf("codehost.label_not_found", name)
+		}
+	}
+	return ids, nil
+}
+
+// milestoneID resolve o milestone pelo ID ou pelo título entre os abertos
+func (g *Gitea) milestoneID(repoPath, milestone string) (int, error) {
+	if id, err := strconv.Atoi(milestone); err == nil {
+		return id, nil
+	}
+
+	var milestones []struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+	if err := g.client.do("GET", repoPath+"/milestones?state=open&limit=100", nil, &milestones); err != nil {
+		return 0, err
+	}
+	for _, candidate := range milestones {
+		if strings.EqualFold(candidate.Title, milestone) {
+			return candidate.ID, nil
+		}
+	}
+	return 0, i18n.Errorf("codehost.milestone_not_found", milestone)
+}
@@ -0,0 +1,171 @@
+package codehost
+
+import (
+	"fmt"
+	"gojira/utils/i18n"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultGitHubAPIURL é a URL da API do GitHub.com; no GitHub Enterprise, é https://<host>/api/v3
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHub cria e atualiza pull requests pela API REST do GitHub e do GitHub Enterprise
+type GitHub struct {
+	client *client
+	owner  string
+	repo   string
+}
+
+// githubPull representa um pull request na resposta da API
+type githubPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// NewGitHub cria um cliente para o repositório owner/repo na API informada, autenticado pelo token
+func NewGitHub(apiURL, token, owner, repo string) *GitHub {
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	return &GitHub{
+		client: newClient("GitHub", apiURL, map[string]string{
+			"Authorization":        "Bearer " + token,
+			"Accept":               "application/vnd.github+json",
+			"X-GitHub-Api-Version": "2022-11-28",
+		}),
+		owner: owner,
+		repo:  repo,
+	}
+}
+
+// Name retorna o nome da plataforma
+func (g *GitHub) Name() string {
+	return "GitHub"
+}
+
+// GitHubHost retorna o host web correspondente à URL da API (github.com para a API pública)
+func GitHubHost(apiURL string) string {
+	if apiURL == "" || strings.TrimRight(apiURL, "/") == DefaultGitHubAPIURL {
+		return "github.com"
+	}
+	parsed, err := url.Parse(apiURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// CreateOrUpdate cria o pull request da branch ou, se já houver um aberto, atualiza título, descrição
+// e base. Em seguida, pede as revisões e define labels, responsáveis e milestone.
+func (g *GitHub) CreateOrUpdate(pr *PullRequest) (*PullRequestResult, error) {
+	repoPath := fmt.Sprintf("/repos/%s/%s", url.PathEscape(g.owner), url.PathEscape(g.repo))
+
+	// A branch de um fork é identificada pelo dono do fork (dono:branch)
+	headOwner := g.owner
+	if pr.HeadOwner != "" {
+		headOwner = pr.HeadOwner
+	}
+	existing, err := g.findOpen(repoPath, headOwner, pr.Head)
+	if err != nil {
+		return nil, err
+	}
+
+	var pull githubPull
+	result := &PullRequestResult{}
+	if existing != nil {
+		// O estado de rascunho só pode ser alterado pela API GraphQL, então é mantido
+		body := map[string]interface{}{"title": pr.Title, "body": pr.Body}
+		if pr.Base != "" {
+			body["base"] = pr.Base
+		}
+		if err := g.client.do("PATCH", fmt.Sprintf("%s/pulls/%d", repoPath, existing.Number), body, &pull); err != nil {
+			return nil, err
+		}
+		result.Updated = true
+	} else {
+		head := pr.Head
+		if !strings.EqualFold(headOwner, g.owner) {
+			head = headOwner + ":" + pr.Head
+		}
+		body := map[string]interface{}{"title": pr.Title, "body": pr.Body, "head": head, "base": pr.Base, "draft": pr.Draft}
+		if err := g.client.do("POST", repoPath+"/pulls", body, &pull); err != nil {
+			return nil, err
+		}
+	}
+	result.Number = pull.Number
+	result.URL = pull.HTMLURL
+
+	if err := g.requestReviewers(repoPath, pull.Number, pr.Reviewers); err != nil {
+		return result, err
+	}
+
+	// Pull requests são issues no GitHub: labels, responsáveis e milestone vão pela API de issues
+	issuePath := fmt.Sprintf("%s/issues/%d", repoPath, pull.Number)
+	if len(pr.Labels) > 0 {
+		if err := g.client.do("POST", issuePath+"/labels", map[string]interface{}{"labels": pr.Labels}, nil); err != nil {
+			return result, err
+		}
+	}
+	if len(pr.Assignees) > 0 {
+		if err := g.client.do("POST", issuePath+"/assignees", map[string]interface{}{"assignees": pr.Assignees}, nil); err != nil {
+			return result, err
+		}
+	}
+	if pr.Milestone != "" {
+		number, err := g.milestoneNumber(repoPath, pr.Milestone)
+		if err != nil {
+			return result, err
+		}
+		if err := g.client.do("PATCH", issuePath, map[string]interface{}{"milestone": number}, nil); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// findOpen retorna o pull request aberto a partir da branch do repositório de headOwner, se houver
+func (g *GitHub) findOpen(repoPath, headOwner, head string) (*githubPull, error) {
+	query := url.Values{"state": {"open"}, "head": {headOwner + ":" + head}}
+	var pulls []githubPull
+	if err := g.client.do("GET", repoPath+"/pulls?"+query.Encode(), nil, &pulls); err != nil {
+		return nil, err
+	}
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	return &pulls[0], nil
+}
+
+// requestReviewers pede a revisão de usuários e de times, informados como org/time
+func (g *GitHub) requestReviewers(repoPath string, number int, reviewers []string) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	users, teams := splitReviewers(reviewers)
+	body := map[string]interface{}{"reviewers": users, "team_reviewers": teams}
+	return g.client.do("POST", fmt.Sprintf("%s/pulls/%d/requested_reviewers", repoPath, number), body, nil)
+}
+
+// milestoneNumber resolve o milestone pelo número ou pelo título entre os milestones abertos
+func (g *GitHub) milestoneNumber(repoPath, milestone string) (int, error) {
+	if number, err := strconv.Atoi(milestone); err == nil {
+		return number, nil
+	}
+
+	var milestones []struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	}
+	if err := g.client.do("GET", repoPath+"/milestones?state=open&per_page=100", nil, &milestones); err != nil {
+		return 0, err
+	}
+	for _, candidate := range milestones {
+		if strings.EqualFold(candidate.Title, milestone) {
+			return candidate.Number, nil
+		}
+	}
+	return 0, i18n.Errorf("codehost.milestone_not_found", milestone)
+}
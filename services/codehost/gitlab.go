@@ -0,0 +1,140 @@
+package codehost
+
+import (
+	"fmt"
+	"gojira/utils/i18n"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GitLab cria e atualiza merge requests pela API REST v4 do GitLab
+type GitLab struct {
+	client  *client
+	project string
+}
+
+// gitlabMergeRequest representa um merge request na resposta da API
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+	Draft  bool   `json:"draft"`
+}
+
+// NewGitLab cria um cliente para o projeto (grupo/projeto) na instância informada
+func NewGitLab(baseURL, token, project string) *GitLab {
+	return &GitLab{
+		client:  newClient("GitLab", baseURL+"/api/v4", map[string]string{"PRIVATE-TOKEN": token}),
+		project: project,
+	}
+}
+
+// Name retorna o nome da plataforma
+func (g *GitLab) Name() string {
+	return "GitLab"
+}
+
+// CreateOrUpdate cria o merge request da branch ou atualiza o que já estiver aberto. O rascunho é
+// indicado pelo prefixo "Draft:" no título, e a base é a branch de destino (target_branch).
+func (g *GitLab) CreateOrUpdate(pr *PullRequest) (*PullRequestResult, error) {
+	projectPath := "/projects/" + url.PathEscape(g.project)
+
+	query := url.Values{"state": {"opened"}, "source_branch": {pr.Head}}
+	var existing []gitlabMergeRequest
+	if err := g.client.do("GET", projectPath+"/merge_requests?"+query.Encode(), nil, &existing); err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{"description": pr.Body}
+	if pr.Base != "" {
+		body["target_branch"] = pr.Base
+	}
+	if len(pr.Reviewers) > 0 {
+		ids, err := g.userIDs(pr.Reviewers)
+		if err != nil {
+			return nil, err
+		}
+		body["reviewer_ids"] = ids
+	}
+	if len(pr.Assignees) > 0 {
+		ids, err := g.userIDs(pr.Assignees)
+		if err != nil {
+			return nil, err
+		}
+		body["assignee_ids"] = ids
+	}
+	if pr.Milestone != "" {
+		id, err := g.milestoneID(projectPath, pr.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		body["milestone_id"] = id
+	}
+
+	var mergeRequest gitlabMergeRequest
+	result := &PullRequestResult{}
+	if len(existing) > 0 {
+		// Um merge request que já é rascunho continua assim
+		body["title"] = draftTitle("Draft: ", pr.Title, pr.Draft || existing[0].Draft)
+		// Na atualização, labels substituiria as labels atuais; add_labels apenas as acrescenta
+		if len(pr.Labels) > 0 {
+			body["add_labels"] = strings.Join(pr.Labels, ",")
+		}
+		path := fmt.Sprintf("%s/merge_requests/%d", projectPath, existing[0].IID)
+		if err := g.client.do("PUT", path, body, &mergeRequest); err != nil {
+			return nil, err
+		}
+		result.Updated = true
+	} else {
+		body["title"] = draftTitle("Draft: ", pr.Title, pr.Draft)
+		body["source_branch"] = pr.Head
+		if len(pr.Labels) > 0 {
+			body["labels"] = strings.Join(pr.Labels, ",")
+		}
+		if err := g.client.do("POST", projectPath+"/merge_requests", body, &mergeRequest); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Number = mergeRequest.IID
+	result.URL = mergeRequest.WebURL
+	return result, nil
+}
+
+// userIDs resolve os nomes de usuário para os IDs numéricos exigidos pela API
+func (g *GitLab) userIDs(usernames []string) ([]int, error) {
+	ids := []int{}
+	for _, username := range usernames {
+		var users []struct {
+			ID int `json:"id"`
+		}
+		if err := g.client.do("GET", "/users?username="+url.QueryEscape(username), nil, &users); err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, i18n.Errorf("codehost.user_not_found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// milestoneID resolve o milestone pelo número no projeto (o iid, exibido na interface) ou pelo
+// título e retorna o ID global exigido pela API
+func (g *GitLab) milestoneID(projectPath, milestone string) (int, error) {
+	query := url.Values{"title": {milestone}}
+	if _, err := strconv.Atoi(milestone); err == nil {
+		query = url.Values{"iids[]": {milestone}}
+	}
+
+	var milestones []struct {
+		ID int `json:"id"`
+	}
+	if err := g.client.do("GET", projectPath+"/milestones?"+query.Encode(), nil, &milestones); err != nil {
+		return 0, err
+	}
+	if len(milestones) == 0 {
+		return 0, i18n.Errorf("codehost.milestone_not_found", milestone)
+	}
+	return milestones[0].ID, nil
+}
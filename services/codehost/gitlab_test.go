@@ -0,0 +1,88 @@
+package codehost
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestGitLabCreatesMergeRequestWithLabels(t *testing.T) {
+	api := newFakeAPI(t, map[string]fakeResponse{
+		"GET /api/v4/projects/group/app/merge_requests":  {body: `[]`},
+		"GET /api/v4/projects/group/app/milestones":      {body: `[{"id": 41}]`},
+		"POST /api/v4/projects/group/app/merge_requests": {body: `{"iid": 3, "web_url": "https://gitlab.com/group/app/-/merge_requests/3"}`},
+	})
+
+	result, err := NewGitLab(api.server.URL, "secret", "group/app").CreateOrUpdate(&PullRequest{
+		Title:     "Login",
+		Head:      "feature/login",
+		Base:      "main",
+		Draft:     true,
+		Labels:    []string{"bug", "backend"},
+		Milestone: "Sprint 2",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrUpdate: %v", err)
+	}
+	if result.Number != 3 || result.Updated {
+		t.Errorf("resultado = %+v, esperado o MR 3 criado", result)
+	}
+
+	create, _ := api.call("POST", "/api/v4/projects/group/app/merge_requests")
+	if create.Body["labels"] != "bug,backend" || create.Body["title"] != "Draft: Login" || create.Body["milestone_id"] != float64(41) {
+		t.Errorf("corpo da criação = %v", create.Body)
+	}
+	milestones, _ := api.call("GET", "/api/v4/projects/group/app/milestones")
+	if query, _ := url.ParseQuery(milestones.Query); query.Get("title") != "Sprint 2" {
+		t.Errorf("busca do milestone com query %q", milestones.Query)
+	}
+}
+
+func TestGitLabUpdateAddsLabelsInsteadOfReplacingThem(t *testing.T) {
+	api := newFakeAPI(t, map[string]fakeResponse{
+		"GET /api/v4/projects/group/app/merge_requests":   {body: `[{"iid": 8, "draft": false}]`},
+		"GET /api/v4/projects/group/app/milestones":       {body: `[{"id": 77}]`},
+		"PUT /api/v4/projects/group/app/merge_requests/8": {body: `{"iid": 8}`},
+	})
+
+	result, err := NewGitLab(api.server.URL, "secret", "group/app").CreateOrUpdate(&PullRequest{
+		Title:     "Login",
+		Head:      "feature/login",
+		Labels:    []string{"bug"},
+		Milestone: "3",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrUpdate: %v", err)
+	}
+	if !result.Updated {
+		t.Errorf("resultado = %+v, esperado o MR atualizado", result)
+	}
+
+	update, _ := api.call("PUT", "/api/v4/projects/group/app/merge_requests/8")
+	if _, replaces := update.Body["labels"]; replaces {
+		t.Errorf("a atualização substitui as labels: %v", update.Body)
+	}
+	if update.Body["add_labels"] != "bug" {
+		t.Errorf("add_labels = %v, esperado bug", update.Body["add_labels"])
+	}
+
+	// Um número é o iid do milestone no projeto, convertido para o ID global
+	milestones, _ := api.call("GET", "/api/v4/projects/group/app/milestones")
+	if query, _ := url.ParseQuery(milestones.Query); query.Get("iids[]") != "3" {
+		t.Errorf("busca do milestone com query %q", milestones.Query)
+	}
+	if update.Body["milestone_id"] != float64(77) {
+		t.Errorf("milestone_id = %v, esperado 77", update.Body["milestone_id"])
+	}
+}
+
+func TestGitLabReportsUnknownMilestone(t *testing.T) {
+	api := newFakeAPI(t, map[string]fakeResponse{
+		"GET /api/v4/projects/group/app/merge_requests": {body: `[]`},
+		"GET /api/v4/projects/group/app/milestones":     {body: `[]`},
+	})
+
+	_, err := NewGitLab(api.server.URL, "secret", "group/app").CreateOrUpdate(&PullRequest{Head: "x", Milestone: "12"})
+	if err == nil {
+		t.Error("esperado erro para milestone inexistente")
+	}
+}
@@ -0,0 +1,193 @@
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Entry representa uma geração registrada no histórico. Prompt é o texto da requisição para
+// exibição; Request guarda a requisição completa (prompt de sistema, mensagens, parâmetros e schema)
+// para que o rerun a repita exatamente.
+type Entry struct {
+	ID           string          `json:"id"`
+	Command      string          `json:"command"`
+	Prompt       string          `json:"prompt"`
+	Request      json.RawMessage `json:"request,omitempty"`
+	Response     string          `json:"response"`
+	Provider     string          `json:"provider"`
+	Model        string          `json:"model"`
+	DurationMs   int64           `json:"duration_ms"`
+	Cached       bool            `json:"cached,omitempty"`
+	InputTokens  int             `json:"input_tokens,omitempty"`
+	OutputTokens int             `json:"output_tokens,omitempty"`
+	CostUSD      float64         `json:"cost_usd,omitempty"`
+	Files        []string        `json:"files,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// currentCommand é o comando em execução, usado para identificar as gerações
+var currentCommand string
+
+// SetCommand define o comando em execução
+func SetCommand(name string) {
+	currentCommand = name
+}
+
+// CurrentCommand retorna o comando em execução
+func CurrentCommand() string {
+	return currentCommand
+}
+
+// GetHistoryDir retorna o diretório onde o histórico é armazenado
+func GetHistoryDir() string {
+	return filepath.Join(commons.GetDataDir(), "history")
+}
+
+// Record grava uma geração no histórico e aplica a retenção configurada.
+// Cada geração fica em seu próprio arquivo, o que evita conflitos entre execuções simultâneas.
+func Record(entry *Entry) error {
+	dir := GetHistoryDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return i18n.Errorf("history.dir_error", err)
+	}
+
+	if entry.Command == "" {
+		entry.Command = currentCommand
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.ID = newID(entry.CreatedAt)
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return i18n.Errorf("history.serialize_error", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, entry.ID+".json"), data, 0600); err != nil {
+		return i18n.Errorf("history.write_error", err)
+	}
+
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return nil
+	}
+	if config.HistoryRetentionDays > 0 || config.HistoryMaxEntries > 0 {
+		_, err = Prune(time.Duration(config.HistoryRetentionDays)*24*time.Hour, config.HistoryMaxEntries)
+	}
+	return err
+}
+
+// List retorna as gerações do histórico, da mais recente para a mais antiga
+func List() ([]*Entry, error) {
+	files, err := os.ReadDir(GetHistoryDir())
+	if os.IsNotExist(err) {
+		return []*Entry{}, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("history.read_error", err)
+	}
+
+	entries := []*Entry{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		entry, err := readEntry(filepath.Join(GetHistoryDir(), file.Name()))
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+
+	return entries, nil
+}
+
+// Get busca uma geração pelo ID, aceitando um prefixo único do ID
+func Get(id string) (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Entry
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+		if strings.HasPrefix(entry.ID, id) {
+			if found != nil {
+				return nil, i18n.Errorf("history.ambiguous_id", id)
+			}
+			found = entry
+		}
+	}
+
+	if found == nil {
+		return nil, i18n.Errorf("history.not_found", id)
+	}
+	return found, nil
+}
+
+// Prune remove as gerações mais antigas que olderThan e mantém no máximo keep gerações.
+// Valores zero desativam o respectivo critério. Retorna o número de gerações removidas.
+func Prune(olderThan time.Duration, keep int) (int, error) {
+	if olderThan <= 0 && keep <= 0 {
+		return 0, errors.New(i18n.T("history.no_retention"))
+	}
+
+	entries, err := List()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	limit := time.Now().Add(-olderThan)
+	for i, entry := range entries {
+		expired := olderThan > 0 && entry.CreatedAt.Before(limit)
+		exceeded := keep > 0 && i >= keep
+		if !expired && !exceeded {
+			continue
+		}
+		if err := os.Remove(filepath.Join(GetHistoryDir(), entry.ID+".json")); err != nil && !os.IsNotExist(err) {
+			return removed, i18n.Errorf("history.remove_error", entry.ID, err)
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// readEntry lê uma geração de um arquivo do histórico
+func readEntry(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// newID gera um ID ordenável pelo horário da geração
+func newID(createdAt time.Time) string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return createdAt.Format("20060102-150405") + "-" + hex.EncodeToString(suffix)
+}
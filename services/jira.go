@@ -3,8 +3,10 @@ package services
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"gojira/utils/commons"
+	"gojira/utils/i18n"
 	"io"
 	"net/http"
 	"strings"
@@ -21,22 +23,22 @@ const (
 
 // JiraIssue representa uma tarefa no Jira
 type JiraIssue struct {
-	Key         string       `json:"key,omitempty"`
-	Summary     string       `json:"summary"`
-	Description string       `json:"description"`
+	Key         string        `json:"key,omitempty"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description"`
 	Type        JiraIssueType `json:"type"`
-	ProjectKey  string       `json:"projectKey"`
+	ProjectKey  string        `json:"projectKey"`
 }
 
 // GetJiraIssue busca uma tarefa no Jira pelo ID
 func GetJiraIssue(issueID string) (*JiraIssue, error) {
 	config, err := commons.LoadConfig()
 	if err != nil {
-		return nil, fmt.Errorf("erro ao carregar configuração: %w", err)
+		return nil, i18n.Errorf("config.load_error", err)
 	}
 
 	if config.JiraURL == "" || config.JiraToken == "" {
-		return nil, fmt.Errorf("URL do Jira ou token de autenticação não configurados")
+		return nil, errors.New(i18n.T("jira.not_configured"))
 	}
 
 	url := fmt.Sprintf("%s/rest/api/2/issue/%s", config.JiraURL, issueID)
@@ -58,7 +60,7 @@ func GetJiraIssue(issueID string) (*JiraIssue, error) {
 	}(resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("erro ao buscar tarefa no Jira: %d", resp.StatusCode)
+		return nil, i18n.Errorf("jira.fetch_status_error", resp.StatusCode)
 	}
 
 	var result map[string]interface{}
@@ -68,24 +70,24 @@ func GetJiraIssue(issueID string) (*JiraIssue, error) {
 
 	fields, ok := result["fields"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("formato de resposta do Jira inválido")
+		return nil, errors.New(i18n.T("jira.invalid_response"))
 	}
 
 	summary, _ := fields["summary"].(string)
 	description, _ := fields["description"].(string)
-	
+
 	issueTypeField, ok := fields["issuetype"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("formato de tipo de tarefa do Jira inválido")
+		return nil, errors.New(i18n.T("jira.invalid_issue_type"))
 	}
-	
+
 	issueTypeName, _ := issueTypeField["name"].(string)
-	
+
 	projectField, ok := fields["project"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("formato de projeto do Jira inválido")
+		return nil, errors.New(i18n.T("jira.invalid_project"))
 	}
-	
+
 	projectKey, _ := projectField["key"].(string)
 
 	var issueType JiraIssueType
@@ -111,11 +113,11 @@ func GetJiraIssue(issueID string) (*JiraIssue, error) {
 func CreateJiraIssue(issue *JiraIssue) (string, error) {
 	config, err := commons.LoadConfig()
 	if err != nil {
-		return "", fmt.Errorf("erro ao carregar configuração: %w", err)
+		return "", i18n.Errorf("config.load_error", err)
 	}
 
 	if config.JiraURL == "" || config.JiraToken == "" {
-		return "", fmt.Errorf("URL do Jira ou token de autenticação não configurados")
+		return "", errors.New(i18n.T("jira.not_configured"))
 	}
 
 	// Se o projeto não for especificado, usa o padrão da configuração
@@ -124,11 +126,11 @@ func CreateJiraIssue(issue *JiraIssue) (string, error) {
 	}
 
 	if issue.ProjectKey == "" {
-		return "", fmt.Errorf("projeto Jira não especificado")
+		return "", errors.New(i18n.T("jira.no_project"))
 	}
 
 	url := fmt.Sprintf("%s/rest/api/2/issue", config.JiraURL)
-	
+
 	// Mapeia o tipo de tarefa para o ID correspondente
 	var issueTypeId string
 	switch issue.Type {
@@ -146,7 +148,7 @@ func CreateJiraIssue(issue *JiraIssue) (string, error) {
 			"project": map[string]string{
 				"key": issue.ProjectKey,
 			},
-			"summary": issue.Summary,
+			"summary":     issue.Summary,
 			"description": issue.Description,
 			"issuetype": map[string]string{
 				"id": issueTypeId,
@@ -177,7 +179,7 @@ func CreateJiraIssue(issue *JiraIssue) (string, error) {
 	}(resp.Body)
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("erro ao criar tarefa no Jira: %d", resp.StatusCode)
+		return "", i18n.Errorf("jira.create_status_error", resp.StatusCode)
 	}
 
 	var result map[string]interface{}
@@ -187,8 +189,122 @@ func CreateJiraIssue(issue *JiraIssue) (string, error) {
 
 	issueKey, ok := result["key"].(string)
 	if !ok {
-		return "", fmt.Errorf("erro ao obter chave da tarefa criada")
+		return "", errors.New(i18n.T("jira.created_key_error"))
 	}
 
 	return issueKey, nil
-}
\ No newline at end of file
+}
+
+// JiraIssueURL retorna o endereço da tarefa na interface web do Jira
+func JiraIssueURL(issueKey string) (string, error) {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return "", i18n.Errorf("config.load_error", err)
+	}
+
+	if config.JiraURL == "" || config.JiraToken == "" {
+		return "", errors.New(i18n.T("jira.not_configured"))
+	}
+	return strings.TrimRight(config.JiraURL, "/") + "/browse/" + issueKey, nil
+}
+
+// AddJiraRemoteLink adiciona à tarefa um link remoto para o endereço informado. O endereço é também o
+// globalId do link, então repetir a chamada atualiza o link existente em vez de criar outro.
+func AddJiraRemoteLink(issueKey, linkURL, title string) error {
+	body := map[string]interface{}{
+		"globalId":     linkURL,
+		"relationship": "pull request",
+		"object": map[string]string{
+			"url":   linkURL,
+			"title": title,
+		},
+	}
+	return jiraRequest("POST", "/rest/api/2/issue/"+issueKey+"/remotelink", body, nil)
+}
+
+// TransitionJiraIssue move a tarefa pela transição com o nome informado ou que leva ao status com esse
+// nome (ex.: "Code Review"). Retorna false se a tarefa já estiver no status.
+func TransitionJiraIssue(issueKey, target string) (bool, error) {
+	var issue struct {
+		Fields struct {
+			Status struct {
+				Name string `json:"name"`
+			} `json:"status"`
+		} `json:"fields"`
+	}
+	if err := jiraRequest("GET", "/rest/api/2/issue/"+issueKey+"?fields=status", nil, &issue); err != nil {
+		return false, err
+	}
+	if strings.EqualFold(issue.Fields.Status.Name, target) {
+		return false, nil
+	}
+
+	var available struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := jiraRequest("GET", "/rest/api/2/issue/"+issueKey+"/transitions", nil, &available); err != nil {
+		return false, err
+	}
+
+	names := []string{}
+	for _, transition := range available.Transitions {
+		if strings.EqualFold(transition.Name, target) || strings.EqualFold(transition.To.Name, target) {
+			body := map[string]interface{}{"transition": map[string]string{"id": transition.ID}}
+			return true, jiraRequest("POST", "/rest/api/2/issue/"+issueKey+"/transitions", body, nil)
+		}
+		names = append(names, transition.Name)
+	}
+	return false, i18n.Errorf("jira.transition_not_found", target, issueKey, strings.Join(names, ", "))
+}
+
+// jiraRequest envia uma requisição autenticada à API do Jira e decodifica a resposta em target, se informado
+func jiraRequest(method, path string, body, target interface{}) error {
+	config, err := commons.LoadConfig()
+	if err != nil {
+		return i18n.Errorf("config.load_error", err)
+	}
+
+	if config.JiraURL == "" || config.JiraToken == "" {
+		return errors.New(i18n.T("jira.not_configured"))
+	}
+
+	var reader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewBuffer(jsonBody)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimRight(config.JiraURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+config.JiraToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		_ = Body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return i18n.Errorf("jira.request_status_error", method, path, resp.StatusCode)
+	}
+	if target == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(target)
+}
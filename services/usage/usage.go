@@ -0,0 +1,165 @@
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Ações possíveis quando o orçamento mensal é excedido
+const (
+	BudgetWarn  = "warn"
+	BudgetBlock = "block"
+)
+
+// Agrupamentos suportados pelo relatório de uso
+const (
+	ByDay     = "day"
+	ByCommand = "command"
+	ByModel   = "model"
+)
+
+// Record representa o consumo de tokens de uma chamada a um provedor de IA
+type Record struct {
+	Command      string    `json:"command"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	CostUSD      float64   `json:"cost_usd"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Summary representa o consumo agregado de um dia, comando ou modelo
+type Summary struct {
+	Key          string  `json:"key"`
+	Calls        int     `json:"calls"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+}
+
+// GetUsageDir retorna o diretório onde o consumo é registrado
+func GetUsageDir() string {
+	return filepath.Join(commons.GetDataDir(), "usage")
+}
+
+// Add registra o consumo de uma chamada no arquivo do mês correspondente.
+// Cada registro é uma linha JSON acrescentada ao arquivo, o que mantém escritas simultâneas íntegras.
+func Add(record *Record) error {
+	dir := GetUsageDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return i18n.Errorf("usage.dir_error", err)
+	}
+
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return i18n.Errorf("usage.write_error", err)
+	}
+
+	file, err := os.OpenFile(monthFile(record.CreatedAt), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return i18n.Errorf("usage.write_error", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return i18n.Errorf("usage.write_error", err)
+	}
+	return nil
+}
+
+// List retorna os registros de consumo do mês informado, do mais antigo para o mais recente
+func List(month time.Time) ([]*Record, error) {
+	file, err := os.Open(monthFile(month))
+	if os.IsNotExist(err) {
+		return []*Record{}, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("usage.read_error", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	records := []*Record{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		// Linhas corrompidas (ex: escrita interrompida) são ignoradas
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, &record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, i18n.Errorf("usage.read_error", err)
+	}
+
+	return records, nil
+}
+
+// MonthTotal retorna o custo total registrado no mês informado
+func MonthTotal(month time.Time) (float64, error) {
+	records, err := List(month)
+	if err != nil {
+		return 0, err
+	}
+
+	var total float64
+	for _, record := range records {
+		total += record.CostUSD
+	}
+	return total, nil
+}
+
+// Group agrega os registros por dia, comando ou modelo, ordenados pela chave
+func Group(records []*Record, by string) []*Summary {
+	summaries := map[string]*Summary{}
+	for _, record := range records {
+		var key string
+		switch by {
+		case ByCommand:
+			key = record.Command
+		case ByModel:
+			key = record.Provider + "/" + record.Model
+		default:
+			key = record.CreatedAt.Local().Format("2006-01-02")
+		}
+
+		summary, exists := summaries[key]
+		if !exists {
+			summary = &Summary{Key: key}
+			summaries[key] = summary
+		}
+		summary.Calls++
+		summary.InputTokens += record.InputTokens
+		summary.OutputTokens += record.OutputTokens
+		summary.CostUSD += record.CostUSD
+	}
+
+	result := make([]*Summary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// monthFile retorna o arquivo de consumo do mês de uma data
+func monthFile(date time.Time) string {
+	return filepath.Join(GetUsageDir(), date.Local().Format("2006-01")+".jsonl")
+}
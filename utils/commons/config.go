@@ -2,7 +2,8 @@ package commons
 
 import (
 	"encoding/json"
-	"fmt"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 	"os"
 	"path/filepath"
 )
@@ -14,22 +15,78 @@ type Config struct {
 	DefaultJira string `json:"default_jira"` // ID do projeto Jira padrão
 	JiraURL     string `json:"jira_url"`     // URL da instância do Jira
 	JiraToken   string `json:"jira_token"`   // Token de autenticação do Jira
+	Language    string `json:"language"`     // Idioma do conteúdo gerado (pt, en)
+	UILanguage  string `json:"ui_language"`  // Idioma das mensagens da CLI (padrão: o mesmo de Language)
+
+	HistoryRetentionDays int `json:"history_retention_days"` // Dias que as gerações ficam no histórico (0 para sempre)
+	HistoryMaxEntries    int `json:"history_max_entries"`    // Número máximo de gerações no histórico (0 para ilimitado)
+
+	CacheDisabled  bool `json:"cache_disabled"`    // Desativa o cache de respostas
+	CacheTTLHours  int  `json:"cache_ttl_hours"`   // Tempo de vida das respostas em cache (0 para o padrão de 24h)
+	CacheMaxSizeMB int  `json:"cache_max_size_mb"` // Tamanho máximo do cache em disco (0 para o padrão de 50MB)
+
+	MonthlyBudgetUSD float64               `json:"monthly_budget_usd"` // Orçamento mensal em dólares (0 para sem limite)
+	BudgetAction     string                `json:"budget_action"`      // Ação ao exceder o orçamento (warn, block)
+	Prices           map[string]ModelPrice `json:"prices,omitempty"`   // Preços por modelo, sobrepondo a tabela padrão
+
+	RedactionMode string            `json:"redaction_mode"`         // Tratamento de segredos e dados pessoais nos prompts (mask, strict, off)
+	PIIPatterns   map[string]string `json:"pii_patterns,omitempty"` // Padrões adicionais de dados pessoais (nome -> expressão regular)
+
+	MaxTokens int `json:"max_tokens"` // Limite de tokens das respostas (0 para o padrão de cada provedor)
+
+	GitHubToken  string `json:"github_token"`   // Token da API do GitHub (padrão: variáveis GITHUB_TOKEN ou GH_TOKEN)
+	GitHubAPIURL string `json:"github_api_url"` // URL da API do GitHub (padrão: https://api.github.com)
+
+	CodeHost      string `json:"code_host"`       // Plataforma dos PRs (github, gitlab, gitea, bitbucket); vazio para detectar pelo remoto
+	CodeHostURL   string `json:"code_host_url"`   // URL da instância self-hosted (padrão: https://<host do remoto>)
+	CodeHostToken string `json:"code_host_token"` // Token do GitLab, Gitea ou Bitbucket (padrão: GITLAB_TOKEN, GITEA_TOKEN ou BITBUCKET_TOKEN)
+}
+
+// ModelPrice representa o preço de um modelo em dólares por milhão de tokens
+type ModelPrice struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// GetUILanguage retorna o idioma das mensagens da CLI
+func (c *Config) GetUILanguage() string {
+	if c.UILanguage != "" {
+		return c.UILanguage
+	}
+	if c.Language != "" {
+		return c.Language
+	}
+	return i18n.Portuguese
 }
 
 // GetConfigFilePath retorna o caminho para o arquivo de configuração
 func GetConfigFilePath() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Printf("Erro ao obter diretório home: %v\n", err)
+		output.Progress(i18n.T("config.home_error", err))
 		return ".gojira.json"
 	}
 	return filepath.Join(homeDir, ".gojira.json")
 }
 
+// GetDataDir retorna o diretório onde o Gojira guarda seus dados locais (histórico, cache, uso etc.):
+// o de GOJIRA_DATA_DIR, que permite isolar execuções de CI e testes, ou ~/.gojira
+func GetDataDir() string {
+	if dir := os.Getenv("GOJIRA_DATA_DIR"); dir != "" {
+		return dir
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		output.Progress(i18n.T("config.home_error", err))
+		return ".gojira"
+	}
+	return filepath.Join(homeDir, ".gojira")
+}
+
 // LoadConfig carrega a configuração do arquivo
 func LoadConfig() (*Config, error) {
 	configPath := GetConfigFilePath()
-	
+
 	// Verifica se o arquivo existe
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		// Retorna configuração padrão se o arquivo não existir
@@ -38,33 +95,38 @@ func LoadConfig() (*Config, error) {
 			AIModel:    "",
 		}, nil
 	}
-	
+
 	// Lê o arquivo de configuração
 	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, fmt.Errorf("erro ao ler arquivo de configuração: %w", err)
+		return nil, i18n.Errorf("config.read_error", err)
 	}
-	
+
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
-		return nil, fmt.Errorf("erro ao processar arquivo de configuração: %w", err)
+		return nil, i18n.Errorf("config.parse_error", err)
 	}
-	
+
 	return &config, nil
 }
 
 // SaveConfig salva a configuração no arquivo
 func SaveConfig(config *Config) error {
 	configPath := GetConfigFilePath()
-	
+
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
-		return fmt.Errorf("erro ao serializar configuração: %w", err)
+		return i18n.Errorf("config.serialize_error", err)
 	}
-	
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("erro ao salvar arquivo de configuração: %w", err)
+
+	// O arquivo guarda tokens, então só o usuário pode lê-lo; um arquivo criado por versões
+	// anteriores tem as permissões restringidas antes de receber o novo conteúdo
+	if err := os.Chmod(configPath, 0600); err != nil && !os.IsNotExist(err) {
+		return i18n.Errorf("config.write_error", err)
 	}
-	
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
+		return i18n.Errorf("config.write_error", err)
+	}
+
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,47 @@
+package commons
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestSaveConfigRestrictsPermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permissões Unix")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	// Um arquivo existente legível por todos tem as permissões restringidas
+	path := filepath.Join(home, ".gojira.json")
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfig(&Config{GitHubToken: "secret"}); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("permissões = %o, esperado 600", mode)
+	}
+
+	// Um arquivo novo já é criado com elas
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfig(&Config{}); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+	info, err = os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0600 {
+		t.Errorf("permissões do arquivo novo = %o, esperado 600", mode)
+	}
+}
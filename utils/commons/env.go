@@ -1,7 +1,8 @@
 package commons
 
 import (
-	"fmt"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 	"os"
 
 	"github.com/joho/godotenv"
@@ -10,7 +11,7 @@ import (
 func LoadEnv() {
 	err := godotenv.Load(".env")
 	if err != nil {
-		fmt.Println("Aviso: Arquivo .env não encontrado. Verificando variáveis de ambiente do sistema...")
+		output.Progress(i18n.T("env.file_missing"))
 	}
 }
 
@@ -20,6 +21,6 @@ func GetEnv(key string) string {
 		return value
 	}
 
-	fmt.Printf("Aviso: A variável %s não está definida.\n", key)
+	output.Progress(i18n.T("env.var_missing", key))
 	return ""
 }
@@ -0,0 +1,103 @@
+package conventional
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Types são os tipos de commit aceitos pelo formato gerado pelo gojira commit
+var Types = []string{"feat", "fix", "chore", "refactor", "docs", "test", "style"}
+
+// TicketPattern reconhece a chave de um ticket do Jira, como ABCD-1234
+var TicketPattern = regexp.MustCompile(`[A-Z]{2,}-\d+`)
+
+var (
+	headerPattern   = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.*)$`)
+	ticketPrefix    = regexp.MustCompile(`^\[([A-Z]{2,}-\d+)\]\s*`)
+	breakingPattern = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s*(.+)$`)
+	versionPattern  = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)`)
+)
+
+// Commit representa uma mensagem de commit no formato Conventional Commits, como
+// "feat(api)!: [ABC-123] Mensagem"
+type Commit struct {
+	Hash     string `json:"hash,omitempty"`
+	Type     string `json:"type"`
+	Scope    string `json:"scope,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Ticket   string `json:"ticket,omitempty"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body,omitempty"`
+}
+
+// Parse interpreta a mensagem de um commit. Retorna false se o cabeçalho não seguir o formato
+// tipo(escopo)!: mensagem. A quebra de compatibilidade vem do ! ou de um rodapé BREAKING CHANGE.
+func Parse(message string) (*Commit, bool) {
+	header, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	match := headerPattern.FindStringSubmatch(strings.TrimSpace(header))
+	if match == nil {
+		return nil, false
+	}
+
+	commit := &Commit{
+		Type:     strings.ToLower(match[1]),
+		Scope:    strings.TrimSpace(match[2]),
+		Breaking: match[3] == "!",
+		Subject:  strings.TrimSpace(match[4]),
+		Body:     strings.TrimSpace(body),
+	}
+	if ticket := ticketPrefix.FindStringSubmatch(commit.Subject); ticket != nil {
+		commit.Ticket = ticket[1]
+		commit.Subject = strings.TrimSpace(commit.Subject[len(ticket[0]):])
+	} else {
+		commit.Ticket = TicketPattern.FindString(commit.Subject)
+	}
+	if breakingPattern.MatchString(commit.Body) {
+		commit.Breaking = true
+	}
+	return commit, true
+}
+
+// BreakingNote retorna a descrição do rodapé BREAKING CHANGE, ou o assunto se não houver rodapé
+func (c *Commit) BreakingNote() string {
+	if match := breakingPattern.FindStringSubmatch(c.Body); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return c.Subject
+}
+
+// NextVersion sugere a próxima versão semântica a partir da atual: major com quebras de
+// compatibilidade, minor com feat e patch nos demais casos. Antes da 1.0.0, quebras sobem só o
+// minor. Sem versão atual, a sugestão é 0.1.0. Retorna também o tipo de incremento.
+func NextVersion(current string, commits []*Commit) (string, string) {
+	bump := "patch"
+	for _, commit := range commits {
+		if commit.Breaking {
+			bump = "major"
+			break
+		}
+		if commit.Type == "feat" {
+			bump = "minor"
+		}
+	}
+
+	match := versionPattern.FindStringSubmatch(current)
+	if match == nil {
+		return "0.1.0", bump
+	}
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+
+	switch {
+	case bump == "major" && major > 0:
+		major, minor, patch = major+1, 0, 0
+	case bump == "major" || bump == "minor":
+		minor, patch = minor+1, 0
+	default:
+		patch++
+	}
+	return fmt.Sprintf("%s%d.%d.%d", match[1], major, minor, patch), bump
+}
@@ -0,0 +1,121 @@
+package conventional
+
+import (
+	"gojira/utils/i18n"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxHeaderLength é o tamanho máximo da primeira linha da mensagem, em caracteres
+const DefaultMaxHeaderLength = 72
+
+var (
+	bracketPattern  = regexp.MustCompile(`^\[([^\]]*)\]`)
+	trailerPattern  = regexp.MustCompile(`^(BREAKING[ -]CHANGE|[A-Za-z][A-Za-z-]*): `)
+	skippedPrefixes = []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "}
+)
+
+// Rules representa as regras configuráveis da validação de mensagens
+type Rules struct {
+	MaxHeaderLength int  `json:"max_header_length"`
+	RequireTicket   bool `json:"require_ticket"`
+}
+
+// Problem representa uma regra que a mensagem não cumpre
+type Problem struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Skip indica se a mensagem é gerada pelo próprio Git (merge, revert, fixup) e não deve ser validada
+func Skip(message string) bool {
+	for _, prefix := range skippedPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// CleanMessage remove de uma mensagem em edição os comentários do Git e o diff que segue a linha de
+// corte (--verbose), como o Git faz antes de gravar o commit
+func CleanMessage(raw string) string {
+	lines := []string{}
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(line, "# ") && strings.Contains(line, ">8") {
+			break
+		}
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// Lint valida a mensagem contra o padrão "type: [TICKET] Message" gerado pelo gojira commit: tipo
+// permitido, ticket no formato [A-Z]{2,}-\d+ entre colchetes, tamanho da primeira linha, linha em
+// branco após ela e corpo em itens iniciados por "- ". Rodapés como BREAKING CHANGE são aceitos.
+func Lint(message string, rules Rules) []Problem {
+	if rules.MaxHeaderLength <= 0 {
+		rules.MaxHeaderLength = DefaultMaxHeaderLength
+	}
+
+	problems := []Problem{}
+	add := func(rule, key string, args ...interface{}) {
+		problems = append(problems, Problem{Rule: rule, Message: i18n.T(key, args...)})
+	}
+
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	header := lines[0]
+	match := headerPattern.FindStringSubmatch(header)
+	if match == nil {
+		add("header-format", "lint.header_format")
+		return problems
+	}
+
+	if !isType(match[1]) {
+		add("type", "lint.type", match[1], strings.Join(Types, ", "))
+	}
+
+	subject := strings.TrimSpace(match[4])
+	if bracket := bracketPattern.FindStringSubmatch(subject); bracket != nil {
+		if TicketPattern.FindString(bracket[1]) != bracket[1] {
+			add("ticket-format", "lint.ticket_format", bracket[1])
+		}
+		subject = strings.TrimSpace(subject[len(bracket[0]):])
+	} else if rules.RequireTicket {
+		add("ticket-required", "lint.ticket_required")
+	}
+	if subject == "" {
+		add("subject-empty", "lint.subject_empty")
+	}
+
+	if length := utf8.RuneCountInString(header); length > rules.MaxHeaderLength {
+		add("header-length", "lint.header_length", length, rules.MaxHeaderLength)
+	}
+
+	if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+		add("body-blank-line", "lint.body_blank_line")
+	}
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		// Linhas recuadas continuam o item anterior
+		if trimmed == "" || line != trimmed || strings.HasPrefix(line, "- ") || trailerPattern.MatchString(line) {
+			continue
+		}
+		add("body-bullets", "lint.body_bullets", trimmed)
+		break
+	}
+	return problems
+}
+
+// isType indica se o tipo está entre os permitidos, em minúsculas
+func isType(commitType string) bool {
+	for _, allowed := range Types {
+		if commitType == allowed {
+			return true
+		}
+	}
+	return false
+}
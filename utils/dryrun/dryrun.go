@@ -0,0 +1,71 @@
+package dryrun
+
+import (
+	"sync"
+	"unicode/utf8"
+)
+
+// Prompt representa um prompt que seria enviado a um provedor de IA
+type Prompt struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	Text             string  `json:"text"`
+	EstimatedTokens  int     `json:"estimated_tokens"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+// Report representa o que o comando faria se não estivesse em --dry-run
+type Report struct {
+	Prompts []Prompt `json:"prompts"`
+	Actions []string `json:"actions"`
+}
+
+var (
+	// enabled indica que a execução atual é um --dry-run
+	enabled bool
+
+	// report acumula os prompts e as ações evitadas na execução atual
+	report = &Report{Prompts: []Prompt{}, Actions: []string{}}
+
+	// mutex protege o relatório de gerações feitas em paralelo
+	mutex sync.Mutex
+)
+
+// SetEnabled ativa ou desativa o modo --dry-run
+func SetEnabled(value bool) {
+	enabled = value
+}
+
+// IsEnabled indica se a execução atual é um --dry-run
+func IsEnabled() bool {
+	return enabled
+}
+
+// Skip registra uma ação com efeito colateral e indica se ela deve ser evitada.
+// Fora do modo --dry-run, nada é registrado e a ação deve ser executada normalmente.
+func Skip(description string) bool {
+	if !enabled {
+		return false
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	report.Actions = append(report.Actions, description)
+	return true
+}
+
+// AddPrompt registra um prompt que seria enviado ao provedor
+func AddPrompt(prompt Prompt) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	report.Prompts = append(report.Prompts, prompt)
+}
+
+// GetReport retorna os prompts e as ações registrados na execução atual
+func GetReport() *Report {
+	return report
+}
+
+// EstimateTokens estima o número de tokens de um texto (aproximadamente 4 caracteres por token)
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
@@ -2,8 +2,14 @@ package git
 
 import (
 	"errors"
-	"fmt"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
+	"gojira/utils/policy"
+	"net/url"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -12,7 +18,7 @@ func IsGitRepository() (bool, error) {
 	cmd.Stderr = nil
 	err := cmd.Run()
 	if err != nil {
-		return false, errors.New("o diretório atual não foi identificado como um repositório Git")
+		return false, errors.New(i18n.T("git.not_identified"))
 	}
 	return true, nil
 }
@@ -21,52 +27,388 @@ func GetBranchName() (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
-		return "", errors.New("erro ao obter o nome da branch")
+		return "", errors.New(i18n.T("git.branch_error"))
 	}
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GetRepoRoot retorna o diretório raiz do repositório atual
+func GetRepoRoot() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	rootOutput, err := cmd.Output()
+	if err != nil {
+		return "", errors.New(i18n.T("git.not_identified"))
+	}
+	return strings.TrimSpace(string(rootOutput)), nil
+}
+
+// GetRangeDiff retorna o diff de cada arquivo alterado entre from e to, sem os arquivos ignorados
+// e os bloqueados pela política, como GetGitDiff faz com as alterações staged
+func GetRangeDiff(from, to string) (map[string]string, error) {
+	ignoredFiles := GetIgnoredFiles()
+
+	cmd := exec.Command("git", "diff", "--name-only", from+".."+to)
+	namesOutput, err := cmd.Output()
+	if err != nil {
+		return nil, i18n.Errorf("git.range_diff_error", from, to)
+	}
+
+	diffs := make(map[string]string)
+	for _, file := range strings.Split(string(namesOutput), "\n") {
+		file = strings.TrimSpace(file)
+		if file == "" || IsIgnored(file, ignoredFiles) || !policy.Allowed(file) {
+			continue
+		}
+		cmd = exec.Command("git", "diff", from+".."+to, "--", file)
+		diffOutput, err := cmd.Output()
+		if err != nil {
+			return nil, i18n.Errorf("git.file_diff_error", file)
+		}
+		diffs[file] = string(diffOutput)
+	}
+	return diffs, nil
+}
+
+// Commit representa um commit com o hash e a mensagem completa
+type Commit struct {
+	Hash    string `json:"hash"`
+	Message string `json:"message"`
+}
+
+// GetCommits retorna os commits de from (exclusive) até to, do mais recente para o mais antigo,
+// sem os de merge. Com from vazio, retorna todo o histórico até to.
+func GetCommits(from, to string) ([]*Commit, error) {
+	revision := to
+	if from != "" {
+		revision = from + ".." + to
+	}
+	cmd := exec.Command("git", "log", "--no-merges", "--format=%H%x1f%B%x1e", revision)
+	logOutput, err := cmd.Output()
+	if err != nil {
+		return nil, i18n.Errorf("git.log_range_error", revision)
+	}
+
+	commits := []*Commit{}
+	for _, record := range strings.Split(string(logOutput), "\x1e") {
+		hash, message, found := strings.Cut(strings.TrimSpace(record), "\x1f")
+		if found {
+			commits = append(commits, &Commit{Hash: hash, Message: strings.TrimSpace(message)})
+		}
+	}
+	return commits, nil
+}
+
+// GetUnpushedCommits retorna os commits do HEAD que não estão em nenhum remoto
+func GetUnpushedCommits() (map[string]bool, error) {
+	cmd := exec.Command("git", "rev-list", "HEAD", "--not", "--remotes")
+	listOutput, err := cmd.Output()
+	if err != nil {
+		return nil, i18n.Errorf("git.log_range_error", "HEAD --not --remotes")
+	}
+	unpushed := map[string]bool{}
+	for _, hash := range strings.Fields(string(listOutput)) {
+		unpushed[hash] = true
+	}
+	return unpushed, nil
+}
+
+// ResolveCommit retorna o hash completo do commit apontado pela referência
+func ResolveCommit(ref string) (string, error) {
+	revOutput, err := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Output()
+	if err != nil {
+		return "", i18n.Errorf("git.ref_error", ref)
+	}
+	return strings.TrimSpace(string(revOutput)), nil
+}
+
+// RewriteMessages troca as mensagens dos commits informados (hash → nova mensagem), recriando com
+// git commit-tree os commits do mais antigo deles até o HEAD. Árvores, autores e datas de autoria são
+// mantidos, e a branch atual passa a apontar para o novo último commit. Merges no caminho não são aceitos.
+func RewriteMessages(messages map[string]string) (string, error) {
+	head, err := ResolveCommit("HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	// Percorre o HEAD pelo primeiro pai até encontrar todos os commits a reescrever
+	chain := []string{}
+	pending := len(messages)
+	for current := head; pending > 0; {
+		parents, err := exec.Command("git", "rev-list", "--parents", "-n", "1", current).Output()
+		if err != nil {
+			return "", i18n.Errorf("git.rewrite_error", current, err)
+		}
+		fields := strings.Fields(string(parents))
+		if len(fields) > 2 {
+			return "", i18n.Errorf("git.rewrite_merge", current[:7])
+		}
+		chain = append([]string{current}, chain...)
+		if _, found := messages[current]; found {
+			pending--
+		}
+		if len(fields) < 2 {
+			break
+		}
+		current = fields[1]
+	}
+	if pending > 0 {
+		return "", errors.New(i18n.T("git.rewrite_not_ancestor"))
+	}
+
+	parent := ""
+	if parents, err := exec.Command("git", "rev-list", "--parents", "-n", "1", chain[0]).Output(); err == nil {
+		if fields := strings.Fields(string(parents)); len(fields) > 1 {
+			parent = fields[1]
+		}
+	}
+
+	for _, commit := range chain {
+		info, err := exec.Command("git", "log", "-1", "--format=%T%x1f%an%x1f%ae%x1f%aI%x1f%B", commit).Output()
+		if err != nil {
+			return "", i18n.Errorf("git.rewrite_error", commit, err)
+		}
+		fields := strings.SplitN(string(info), "\x1f", 5)
+		if len(fields) < 5 {
+			return "", i18n.Errorf("git.rewrite_error", commit, string(info))
+		}
+		message, found := messages[commit]
+		if !found {
+			message = fields[4]
+		}
+
+		args := []string{"commit-tree", fields[0]}
+		if parent != "" {
+			args = append(args, "-p", parent)
+		}
+		cmd := exec.Command("git", args...)
+		cmd.Stdin = strings.NewReader(strings.TrimSpace(message) + "\n")
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME="+fields[1], "GIT_AUTHOR_EMAIL="+fields[2], "GIT_AUTHOR_DATE="+fields[3])
+		created, err := cmd.Output()
+		if err != nil {
+			return "", i18n.Errorf("git.rewrite_error", commit, err)
+		}
+		parent = strings.TrimSpace(string(created))
+	}
+
+	// A atualização só acontece se o HEAD não tiver mudado durante a reescrita
+	cmd := exec.Command("git", "update-ref", "-m", "gojira: rewrite commit messages", "HEAD", parent, head)
+	if err := cmd.Run(); err != nil {
+		return "", i18n.Errorf("git.rewrite_error", "HEAD", err)
+	}
+	return parent, nil
+}
+
+// GetHooksDir retorna o diretório de hooks do repositório, respeitando core.hooksPath
+func GetHooksDir() (string, error) {
+	hooksOutput, err := exec.Command("git", "rev-parse", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", errors.New(i18n.T("git.not_identified"))
+	}
+	return strings.TrimSpace(string(hooksOutput)), nil
+}
+
+// GetLatestTag retorna a tag mais recente alcançável a partir da referência
+func GetLatestTag(ref string) (string, error) {
+	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0", ref)
+	tagOutput, err := cmd.Output()
+	if err != nil {
+		return "", i18n.Errorf("git.no_tag_error", ref)
+	}
+	return strings.TrimSpace(string(tagOutput)), nil
+}
+
+// GetDefaultBranch resolve a branch padrão do repositório, na ordem: a configuração gojira.baseBranch,
+// o origin/HEAD local, a referência simbólica HEAD do remoto, init.defaultBranch e, por fim, a
+// primeira entre main, master e develop que existir.
+func GetDefaultBranch() (string, error) {
+	if branch := gitConfig("gojira.baseBranch"); branch != "" {
+		return branch, nil
+	}
+
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if headOutput, err := cmd.Output(); err == nil {
+		if branch := strings.TrimPrefix(strings.TrimSpace(string(headOutput)), "origin/"); branch != "" {
+			return branch, nil
+		}
+	}
+
+	// Consulta o remoto sem pedir credenciais; clones antigos não têm o origin/HEAD local
+	cmd = exec.Command("git", "ls-remote", "--symref", "origin", "HEAD")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if remoteOutput, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(remoteOutput), "\n") {
+			ref, found := strings.CutPrefix(line, "ref: refs/heads/")
+			if fields := strings.Fields(ref); found && len(fields) > 0 {
+				return fields[0], nil
+			}
+		}
+	}
+
+	candidates := []string{"main", "master", "develop"}
+	if branch := gitConfig("init.defaultBranch"); branch != "" {
+		candidates = append([]string{branch}, candidates...)
+	}
+	for _, branch := range candidates {
+		if refExists("origin/"+branch) || refExists(branch) {
+			return branch, nil
+		}
+	}
+	return "", errors.New(i18n.T("git.default_branch_error"))
+}
+
+// BaseRef retorna a referência usada para comparar com a branch base: origin/<branch> quando
+// existir, para não depender de uma cópia local desatualizada, ou a própria branch
+func BaseRef(branch string) string {
+	if refExists("origin/" + branch) {
+		return "origin/" + branch
+	}
+	return branch
+}
+
+// GetMergeBase retorna o commit em que head se separou de base
+func GetMergeBase(base, head string) (string, error) {
+	cmd := exec.Command("git", "merge-base", base, head)
+	baseOutput, err := cmd.Output()
+	if err != nil {
+		return "", i18n.Errorf("git.merge_base_error", base, head)
+	}
+	return strings.TrimSpace(string(baseOutput)), nil
+}
+
+// refExists indica se a referência aponta para um commit
+func refExists(ref string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}").Run() == nil
+}
+
+// gitConfig lê um valor da configuração do Git, vazio se não estiver definido
+func gitConfig(key string) string {
+	configOutput, err := exec.Command("git", "config", "--get", key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(configOutput))
+}
+
 func GetGitDiff() (map[string]string, error) {
 	ignoredFiles := GetIgnoredFiles()
 
 	cmd := exec.Command("git", "diff", "--name-only", "--cached")
-	output, err := cmd.Output()
+	namesOutput, err := cmd.Output()
 	if err != nil {
-		return nil, errors.New("erro ao executar git diff --cached")
+		return nil, errors.New(i18n.T("git.diff_cached_error"))
 	}
 
 	modifiedFiles := []string{}
-	lines := strings.Split(string(output), "\n")
+	lines := strings.Split(string(namesOutput), "\n")
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-		if line != "" && !IsIgnored(line, ignoredFiles) {
+		if line != "" && !IsIgnored(line, ignoredFiles) && policy.Allowed(line) {
 			modifiedFiles = append(modifiedFiles, line)
 		}
 	}
 
 	if len(modifiedFiles) == 0 {
-		return nil, errors.New("nenhum arquivo staged encontrado")
+		return nil, errors.New(i18n.T("git.no_staged"))
 	}
 
-	fmt.Printf("Arquivos detectados (staged): %v\n\n", modifiedFiles)
+	output.Progress(i18n.T("git.staged_detected", modifiedFiles))
 
 	diffs := make(map[string]string)
 	for _, file := range modifiedFiles {
 		cmd = exec.Command("git", "diff", "--cached", "--", file) // Obtém o diff somente dos arquivos staged
 		diffOutput, err := cmd.Output()
 		if err != nil {
-			return nil, fmt.Errorf("erro ao obter diff para o arquivo %s", file)
+			return nil, i18n.Errorf("git.file_diff_error", file)
 		}
 		diffs[file] = string(diffOutput)
 	}
 
 	if len(diffs) == 0 {
-		return nil, errors.New("nenhuma diferença encontrada nos arquivos staged")
+		return nil, errors.New(i18n.T("git.no_staged_diff"))
 	}
 
 	return diffs, nil
 }
 
+// DiffText retorna o diff unificado entre duas versões do conteúdo de um arquivo, sem gravá-lo no repositório
+func DiffText(name, before, after string) (string, error) {
+	dir, err := os.MkdirTemp("", "gojira-diff-")
+	if err != nil {
+		return "", i18n.Errorf("git.diff_text_error", err)
+	}
+	defer os.RemoveAll(dir)
+
+	name = filepath.Base(name)
+	for side, content := range map[string]string{"a": before, "b": after} {
+		if err := os.MkdirAll(filepath.Join(dir, side), 0755); err != nil {
+			return "", i18n.Errorf("git.diff_text_error", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, side, name), []byte(content), 0644); err != nil {
+			return "", i18n.Errorf("git.diff_text_error", err)
+		}
+	}
+
+	// Com --no-index, o código de saída 1 indica apenas que as versões são diferentes
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--no-prefix", "--", "a/"+name, "b/"+name)
+	cmd.Dir = dir
+	diffOutput, err := cmd.Output()
+	var exitError *exec.ExitError
+	if err != nil && !(errors.As(err, &exitError) && exitError.ExitCode() == 1) {
+		return "", i18n.Errorf("git.diff_text_error", err)
+	}
+
+	// Descarta o cabeçalho "diff --git" e a linha de índice, mantendo a partir de "---"
+	diff := string(diffOutput)
+	if index := strings.Index(diff, "--- "); index >= 0 {
+		diff = diff[index:]
+	}
+	return diff, nil
+}
+
+// Remote representa um repositório remoto: o host e o caminho owner/repo
+type Remote struct {
+	Host  string `json:"host"`
+	Owner string `json:"owner"`
+	Name  string `json:"name"`
+}
+
+// Path retorna o caminho completo do repositório no host (owner/repo)
+func (r *Remote) Path() string {
+	return r.Owner + "/" + r.Name
+}
+
+// GetRemote lê a URL do remoto com git remote get-url e a interpreta
+func GetRemote(name string) (*Remote, error) {
+	cmd := exec.Command("git", "remote", "get-url", name)
+	remoteOutput, err := cmd.Output()
+	if err != nil {
+		return nil, i18n.Errorf("git.remote_error", name)
+	}
+	return ParseRemoteURL(strings.TrimSpace(string(remoteOutput)))
+}
+
+// ParseRemoteURL interpreta URLs de remotos nos formatos HTTPS, SSH e scp (git@host:owner/repo.git).
+// Em grupos aninhados (GitLab), o owner inclui todos os níveis antes do nome do repositório.
+func ParseRemoteURL(remoteURL string) (*Remote, error) {
+	host, path := "", ""
+	if parsed, err := url.Parse(remoteURL); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		host = parsed.Host
+		if parsed.Scheme == "ssh" || parsed.Scheme == "git" {
+			host = parsed.Hostname()
+		}
+		path = parsed.Path
+	} else if at, rest, found := strings.Cut(remoteURL, "@"); found && !strings.Contains(at, "/") {
+		host, path, _ = strings.Cut(rest, ":")
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	index := strings.LastIndex(path, "/")
+	if host == "" || index <= 0 || index == len(path)-1 {
+		return nil, i18n.Errorf("git.remote_parse_error", remoteURL)
+	}
+	return &Remote{Host: host, Owner: path[:index], Name: path[index+1:]}, nil
+}
+
 func ParseBranchForCommitType(branch string) (string, string, error) {
 	parts := strings.Split(branch, "/")
 	if len(parts) < 2 {
@@ -80,3 +422,18 @@ func ParseBranchForCommitType(branch string) (string, string, error) {
 
 	return commitType, context, nil
 }
+
+// ticketPattern reconhece uma chave de ticket do Jira no início de uma parte da branch (abc-123-descricao)
+var ticketPattern = regexp.MustCompile(`(?i)^([a-z][a-z0-9]{1,9})-(\d+)(?:[-_.]|$)`)
+
+// ParseTicketKey extrai a chave do ticket (ex.: ABC-123) do contexto da branch, como em
+// feature/abc-123-descricao. Retorna vazio se a branch não tiver um ticket.
+func ParseTicketKey(branch string) string {
+	_, context, _ := ParseBranchForCommitType(branch)
+	for _, part := range strings.Split(context, "/") {
+		if match := ticketPattern.FindStringSubmatch(part); match != nil {
+			return strings.ToUpper(match[1]) + "-" + match[2]
+		}
+	}
+	return ""
+}
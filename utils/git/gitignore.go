@@ -1,7 +1,8 @@
 package git
 
 import (
-	"fmt"
+	"gojira/utils/i18n"
+	"gojira/utils/output"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,7 +16,7 @@ func GetIgnoredFiles() []string {
 
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		fmt.Printf("Erro ao ler o .gitignore: %v\n", err)
+		output.Progress(i18n.T("git.gitignore_read_error", err))
 		return []string{}
 	}
 
@@ -34,7 +35,7 @@ func IsIgnored(file string, ignoredPatterns []string) bool {
 	for _, pattern := range ignoredPatterns {
 		matched, err := filepath.Match(pattern, file)
 		if err != nil {
-			fmt.Printf("Erro ao processar o padrão %s: %v\n", pattern, err)
+			output.Progress(i18n.T("git.pattern_error", pattern, err))
 			continue
 		}
 		if matched {
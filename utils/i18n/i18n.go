@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Idiomas suportados pelo catálogo de mensagens e pela geração de conteúdo
+const (
+	Portuguese = "pt"
+	English    = "en"
+)
+
+var (
+	// uiLanguage é o idioma usado nas mensagens da CLI
+	uiLanguage = Portuguese
+
+	// configuredContent é o idioma do conteúdo definido na configuração
+	configuredContent string
+
+	// overrideContent é o idioma do conteúdo definido pela flag --lang
+	overrideContent string
+)
+
+// Normalize converte um nome ou código de idioma para um dos idiomas suportados
+func Normalize(lang string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(lang)) {
+	case "pt", "pt-br", "pt_br", "portugues", "português", "portuguese":
+		return Portuguese, true
+	case "en", "en-us", "en_us", "ingles", "inglês", "english":
+		return English, true
+	default:
+		return "", false
+	}
+}
+
+// SetLanguage define o idioma das mensagens da CLI
+func SetLanguage(lang string) bool {
+	normalized, ok := Normalize(lang)
+	if ok {
+		uiLanguage = normalized
+	}
+	return ok
+}
+
+// Language retorna o idioma atual das mensagens da CLI
+func Language() string {
+	return uiLanguage
+}
+
+// SetContentLanguage define o idioma do conteúdo gerado conforme a configuração
+func SetContentLanguage(lang string) bool {
+	normalized, ok := Normalize(lang)
+	if ok {
+		configuredContent = normalized
+	}
+	return ok
+}
+
+// OverrideContentLanguage define o idioma do conteúdo gerado apenas para o comando atual
+func OverrideContentLanguage(lang string) bool {
+	normalized, ok := Normalize(lang)
+	if ok {
+		overrideContent = normalized
+	}
+	return ok
+}
+
+// ContentLanguage retorna o idioma do conteúdo gerado, usando fallback quando nada foi definido
+func ContentLanguage(fallback string) string {
+	if overrideContent != "" {
+		return overrideContent
+	}
+	if configuredContent != "" {
+		return configuredContent
+	}
+	return fallback
+}
+
+// LanguageName retorna o nome do idioma para ser usado nos prompts
+func LanguageName(lang string) string {
+	if lang == English {
+		return "US English"
+	}
+	return "Brazilian Portuguese"
+}
+
+// PromptInstruction retorna a instrução de idioma a ser anexada aos prompts
+func PromptInstruction(fallback string) string {
+	if ContentLanguage(fallback) == English {
+		return "\n\nWrite the entire response in US English."
+	}
+	return "\n\nEscreva toda a resposta em português do Brasil."
+}
+
+// T retorna a mensagem traduzida para a chave informada, formatada com os argumentos
+func T(key string, args ...interface{}) string {
+	message := lookup(key)
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// TIn retorna a mensagem da chave em um idioma específico, como o do conteúdo gerado, formatada com os argumentos
+func TIn(lang, key string, args ...interface{}) string {
+	message, ok := messages[lang][key]
+	if !ok {
+		message = lookup(key)
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Errorf cria um erro com a mensagem traduzida, preservando o uso de %w
+func Errorf(key string, args ...interface{}) error {
+	return fmt.Errorf(lookup(key), args...)
+}
+
+// lookup busca a mensagem no idioma atual, caindo para português e depois para a própria chave
+func lookup(key string) string {
+	if message, ok := messages[uiLanguage][key]; ok {
+		return message
+	}
+	if message, ok := messages[Portuguese][key]; ok {
+		return message
+	}
+	return key
+}
@@ -0,0 +1,799 @@
+package i18n
+
+// messages é o catálogo de mensagens da CLI, indexado por idioma e chave
+var messages = map[string]map[string]string{
+	Portuguese: {
+		"lang.unsupported":               "Aviso: idioma %q não suportado. Use pt ou en.",
+		"config.home_error":              "Erro ao obter diretório home: %v",
+		"config.read_error":              "erro ao ler arquivo de configuração: %w",
+		"config.parse_error":             "erro ao processar arquivo de configuração: %w",
+		"config.serialize_error":         "erro ao serializar configuração: %w",
+		"config.write_error":             "erro ao salvar arquivo de configuração: %w",
+		"env.file_missing":               "Aviso: Arquivo .env não encontrado. Verificando variáveis de ambiente do sistema...",
+		"env.var_missing":                "Aviso: A variável %s não está definida.",
+		"config.load_error":              "erro ao carregar configuração: %w",
+		"config.save_error":              "erro ao salvar configuração: %w",
+		"config.invalid_language":        "idioma %q não suportado. Use pt ou en",
+		"config.updated":                 "Configuração atualizada com sucesso!",
+		"config.current":                 "Configuração atual:",
+		"config.provider":                "- Provedor de IA: %s",
+		"config.model_default":           "- Modelo de IA: %s (padrão)",
+		"config.model":                   "- Modelo de IA: %s",
+		"config.models_available":        "- Modelos disponíveis:",
+		"config.jira_url":                "- URL do Jira: %s",
+		"config.jira_url_unset":          "- URL do Jira: Não configurado",
+		"config.jira_project":            "- Projeto Jira padrão: %s",
+		"config.jira_project_unset":      "- Projeto Jira padrão: Não configurado",
+		"config.jira_token_set":          "- Token do Jira: Configurado",
+		"config.jira_token_unset":        "- Token do Jira: Não configurado",
+		"config.language":                "- Idioma do conteúdo gerado: %s",
+		"config.language_unset":          "- Idioma do conteúdo gerado: padrão de cada comando",
+		"config.ui_language":             "- Idioma da CLI: %s",
+		"config.providers_available":     "Provedores de IA disponíveis:",
+		"config.provider_models":         "  Modelos disponíveis:",
+		"config.provider_model_default":  "  * %s (padrão)",
+		"git.not_repo":                   "o diretório atual não é um repositório Git",
+		"git.not_identified":             "o diretório atual não foi identificado como um repositório Git",
+		"git.branch_error":               "erro ao obter o nome da branch",
+		"git.diff_cached_error":          "erro ao executar git diff --cached",
+		"git.no_staged":                  "nenhum arquivo staged encontrado",
+		"git.staged_detected":            "Arquivos detectados (staged): %v\n",
+		"git.file_diff_error":            "erro ao obter diff para o arquivo %s",
+		"git.no_staged_diff":             "nenhuma diferença encontrada nos arquivos staged",
+		"git.gitignore_read_error":       "Erro ao ler o .gitignore: %v",
+		"git.pattern_error":              "Erro ao processar o padrão %s: %v",
+		"commit.repo_detected":           "Repositório Git detectado. Preparando diffs...",
+		"commit.suggested":               "\nMensagem de commit sugerida:",
+		"analysis.files_error":           "erro ao obter arquivos do projeto: %w",
+		"analysis.no_files":              "nenhum arquivo relevante encontrado no projeto",
+		"analysis.read_error":            "erro ao ler arquivos do projeto: %w",
+		"analysis.log_error":             "erro ao gravar log da análise: %w",
+		"analysis.log_warning":           "Erro ao gravar log da análise: %v",
+		"analysis.response_error":        "erro ao obter resposta do provedor de IA: %w",
+		"analysis.skip_csproj":           "ignorando arquivo .csproj: %s",
+		"analysis.skip_bin":              "ignorando diretório bin/ ou obj/: %s",
+		"analysis.skip_ide":              "ignorando diretório .idea/ ou .vscode/: %s",
+		"analysis.file_read_error":       "erro ao ler arquivo %s: %v",
+		"analysis.skip_binary":           "ignorando arquivo binário ou muito grande: %s",
+		"analysis.log_dir_error":         "erro ao criar diretório de logs: %w",
+		"analysis.log_file_error":        "erro ao criar arquivo de log: %w",
+		"analysis.log_close_error":       "erro ao fechar arquivo de log: %v",
+		"analysis.wd_error":              "Erro ao obter diretório atual: %v",
+		"analysis.unknown_project":       "Projeto Desconhecido",
+		"readme.repo_check_error":        "erro ao verificar repositório Git: %v",
+		"readme.not_repo":                "diretório não é um repositório Git",
+		"readme.tree_error":              "erro ao executar comando tree: %v",
+		"readme.files_error":             "erro ao obter detalhes dos arquivos: %v",
+		"readme.analysis_files_error":    "erro ao obter detalhes dos arquivos de análise: %v",
+		"readme.save_error":              "erro ao salvar README.md: %v",
+		"readme.generated":               "README.md gerado com sucesso!",
+		"input.issue_key":                "Digite a chave da issue (ex: ABC-123):",
+		"input.issue_key_error":          "erro ao ler a chave da issue: %w",
+		"input.branch_name":              "Digite o nome da branch (sem o prefixo):",
+		"input.branch_name_error":        "erro ao ler o nome da branch: %w",
+		"input.yes":                      "s",
+		"dev.branch_create_error":        "erro ao criar a branch: %w",
+		"dev.branch_created":             "Branch %s criada com sucesso!",
+		"dev.issue_fetch_warning":        "Não foi possível obter detalhes da issue %s: %v",
+		"dev.continue_anyway":            "Deseja continuar mesmo assim? (s/n)",
+		"dev.cancelled":                  "operação cancelada pelo usuário",
+		"dev.task":                       "Tarefa: %s - %s",
+		"dev.suggested_branch":           "Nome sugerido para a branch: %s/%s-%s",
+		"dev.use_name":                   "Deseja usar este nome? (s/n)",
+		"dev.branch_name_custom":         "Digite o nome desejado para a branch (sem o prefixo e sem a issue):",
+		"dev.current_branch_error":       "erro ao obter o nome da branch atual: %w",
+		"dev.issue_fetch_error":          "não foi possível obter detalhes da issue %s: %w",
+		"dev.checklist_error":            "erro ao gerar checklist: %w",
+		"dev.checklist_save_error":       "erro ao salvar checklist: %w",
+		"dev.checklist_saved":            "Checklist gerado e salvo em %s",
+		"dev.checklist":                  "\nChecklist:",
+		"jira.invalid_type":              "tipo de tarefa inválido. Use EPICO, BUG ou TASK",
+		"jira.empty_title":               "o título da tarefa não pode estar vazio",
+		"jira.generated":                 "\nDescrição gerada:",
+		"clipboard.error":                "não foi possível copiar para o clipboard",
+		"jira.copied":                    "\nA descrição foi copiada para o clipboard!",
+		"jira.create_warning":            "\nAtenção: Não foi possível criar a tarefa no Jira: %v",
+		"jira.created":                   "\nTarefa criada no Jira com sucesso: %s",
+		"kanban.jira_incomplete":         "configuração do Jira incompleta. Use 'gojira config' para configurar",
+		"kanban.no_project":              "projeto não especificado. Use --project ou configure um projeto padrão",
+		"kanban.fetching":                "Buscando tarefas do projeto %s...",
+		"kanban.fetch_error":             "erro ao buscar tarefas: %w",
+		"kanban.no_tasks":                "Nenhuma tarefa",
+		"git.must_be_repo":               "este comando deve ser executado dentro de um repositório Git",
+		"pr.generating_title":            "Gerando título para o PR...",
+		"pr.title_error":                 "erro ao gerar título do PR: %w",
+		"pr.title_generated":             "Título gerado: %s",
+		"pr.generating_description":      "Gerando descrição para o PR...",
+		"pr.description_error":           "erro ao gerar descrição do PR: %w",
+		"pr.description_generated":       "Descrição gerada.",
+		"pr.description_save_error":      "erro ao salvar a descrição do PR: %w",
+		"pr.no_cli":                      "não foi encontrado 'gh' (GitHub CLI) ou 'glab' (GitLab CLI). Configure um token do GitHub (gojira config --github-token ou GITHUB_TOKEN) ou instale uma dessas ferramentas para criar PRs",
+		"pr.creating":                    "Criando PR usando %s...",
+		"pr.log_error":                   "erro ao obter log de commits: %w",
+		"pr.title_ai_error":              "erro ao gerar título com IA: %w",
+		"pr.diff_stat_error":             "erro ao obter diff stat: %w",
+		"pr.commits_error":               "erro ao obter commits: %w",
+		"pr.description_ai_error":        "erro ao gerar descrição com IA: %w",
+		"summary.files_error":            "erro ao obter lista de arquivos alterados: %w",
+		"summary.no_changes":             "nenhuma alteração encontrada desde %s",
+		"summary.diff_warning":           "Aviso: Erro ao obter diff para %s: %v",
+		"summary.generating":             "Gerando resumo das alterações...",
+		"summary.error":                  "erro ao gerar resumo: %w",
+		"summary.save_error":             "erro ao salvar o resumo: %w",
+		"summary.saved":                  "Resumo salvo em %s",
+		"standup.collect_error":          "erro ao coletar atividades: %w",
+		"standup.generating":             "Gerando relatório de standup...",
+		"standup.error":                  "erro ao gerar relatório: %w",
+		"standup.save_error":             "erro ao salvar o relatório: %w",
+		"standup.saved":                  "Relatório salvo em %s",
+		"explain.no_file":                "é necessário fornecer o caminho para um arquivo",
+		"explain.file_missing":           "o arquivo %s não existe",
+		"explain.read_error":             "erro ao ler o arquivo: %w",
+		"explain.error":                  "erro ao gerar explicação: %w",
+		"explain.save_error":             "erro ao salvar a explicação: %w",
+		"explain.saved":                  "Explicação salva em %s",
+		"test.no_source":                 "é necessário fornecer o caminho para um arquivo fonte",
+		"test.source_missing":            "o arquivo fonte %s não existe",
+		"test.read_error":                "erro ao ler o arquivo fonte: %w",
+		"test.generating":                "Gerando testes...",
+		"test.error":                     "erro ao gerar testes: %w",
+		"test.save_error":                "erro ao salvar os testes: %w",
+		"test.saved":                     "Testes gerados com sucesso e salvos em %s",
+		"jira.not_configured":            "URL do Jira ou token de autenticação não configurados",
+		"jira.fetch_status_error":        "erro ao buscar tarefa no Jira: %d",
+		"jira.invalid_response":          "formato de resposta do Jira inválido",
+		"jira.invalid_issue_type":        "formato de tipo de tarefa do Jira inválido",
+		"jira.invalid_project":           "formato de projeto do Jira inválido",
+		"jira.no_project":                "projeto Jira não especificado",
+		"jira.create_status_error":       "erro ao criar tarefa no Jira: %d",
+		"jira.created_key_error":         "erro ao obter chave da tarefa criada",
+		"ai.missing_key":                 "%s não fornecido",
+		"ai.api_error":                   "falha na chamada à API %s (%d): %s",
+		"ai.unexpected_response":         "resposta inesperada da API %s",
+		"output.invalid_format":          "formato de saída %q não suportado. Use text ou json",
+		"config.history_retention":       "- Retenção do histórico: %d dias, %d gerações (0 = sem limite)",
+		"history.dir_error":              "erro ao criar diretório do histórico: %w",
+		"history.serialize_error":        "erro ao serializar geração: %w",
+		"history.write_error":            "erro ao gravar geração no histórico: %w",
+		"history.read_error":             "erro ao ler o histórico: %w",
+		"history.ambiguous_id":           "o ID %q corresponde a mais de uma geração",
+		"history.not_found":              "geração %q não encontrada no histórico",
+		"history.no_retention":           "nenhuma retenção definida. Use --days, --keep ou configure com 'gojira config --history-days/--history-max'",
+		"history.remove_error":           "erro ao remover a geração %s: %w",
+		"history.record_warning":         "Aviso: não foi possível gravar a geração no histórico: %v",
+		"history.empty":                  "Nenhuma geração no histórico.",
+		"history.show_header":            "Geração %s (%s) em %s",
+		"history.show_provider":          "Provedor: %s / %s (%s)",
+		"history.show_prompt":            "\n=== Prompt ===",
+		"history.show_response":          "\n=== Resposta ===",
+		"history.unknown_provider":       "provedor de IA %q desconhecido",
+		"history.rerunning":              "Executando novamente a geração %s...",
+		"history.original_response":      "=== Resposta original (%s / %s) ===",
+		"history.new_response":           "\n=== Nova resposta (%s / %s) ===",
+		"history.negative_retention":     "os valores de retenção não podem ser negativos",
+		"history.pruned":                 "%d gerações removidas do histórico.",
+		"cache.dir_error":                "erro ao criar diretório do cache: %v",
+		"cache.write_error":              "erro ao gravar resposta no cache: %v",
+		"cache.read_error":               "erro ao ler o cache: %v",
+		"cache.remove_error":             "erro ao remover resposta do cache: %v",
+		"cache.hit":                      "Usando resposta em cache (use --no-cache para consultar o provedor novamente).",
+		"cache.write_warning":            "Aviso: não foi possível gravar a resposta no cache: %v",
+		"cache.cleared":                  "%d respostas removidas do cache.",
+		"config.cache":                   "- Cache: ativo, validade %d horas, máximo %d MB (0 = padrão)",
+		"config.cache_disabled":          "- Cache: desativado",
+		"usage.dir_error":                "erro ao criar diretório de consumo: %v",
+		"usage.write_error":              "erro ao registrar consumo: %v",
+		"usage.read_error":               "erro ao ler consumo: %v",
+		"usage.record_warning":           "Aviso: não foi possível registrar o consumo de tokens: %v",
+		"usage.budget_check_warning":     "Aviso: não foi possível verificar o orçamento mensal: %v",
+		"usage.budget_warning":           "Aviso: o orçamento mensal foi excedido (US$ %.2f de US$ %.2f).",
+		"usage.budget_exceeded":          "orçamento mensal excedido (US$ %.2f de US$ %.2f); ajuste com gojira config --budget ou --budget-action warn",
+		"usage.summary":                  "Tokens: %d de entrada, %d de saída (custo estimado: US$ %.4f)",
+		"usage.invalid_group":            "agrupamento inválido: %s (use day, command ou model)",
+		"usage.invalid_month":            "mês inválido: %s (use o formato AAAA-MM)",
+		"usage.empty":                    "Nenhum consumo registrado em %s.",
+		"usage.header":                   "Consumo de %s:",
+		"usage.column_day":               "Dia",
+		"usage.column_command":           "Comando",
+		"usage.column_model":             "Modelo",
+		"usage.column_calls":             "Chamadas",
+		"usage.column_input":             "Entrada",
+		"usage.column_output":            "Saída",
+		"usage.column_cost":              "US$",
+		"usage.total":                    "Total",
+		"usage.budget_status":            "Orçamento: US$ %.2f de US$ %.2f (%.0f%%)",
+		"config.invalid_budget_action":   "ação de orçamento inválida: %s (use warn ou block)",
+		"config.invalid_price":           "preço inválido: %s (use modelo=entrada,saída, em dólares por milhão de tokens)",
+		"config.budget":                  "- Orçamento mensal: US$ %.2f (ao exceder: %s)",
+		"config.budget_unset":            "- Orçamento mensal: sem limite",
+		"config.price":                   "- Preço de %s: US$ %.2f entrada / US$ %.2f saída por milhão de tokens",
+		"redact.invalid_pattern":         "padrão de dados pessoais inválido %s: %v",
+		"redact.masked":                  "Dados sensíveis mascarados antes do envio ao provedor: %s",
+		"redact.strict_abort":            "dados sensíveis encontrados no prompt: %s; nada foi enviado ao provedor (modo de redação strict)",
+		"config.invalid_redaction":       "modo de redação inválido: %s (use mask, strict ou off)",
+		"config.invalid_pii_pattern":     "padrão inválido: %s (use nome=expressão)",
+		"config.redaction":               "- Redação de dados sensíveis: %s",
+		"config.pii_pattern":             "- Padrão de dados pessoais %s: %s",
+		"policy.read_error":              "erro ao ler arquivo de política: %v",
+		"policy.parse_error":             "erro ao interpretar arquivo de política %s: %v",
+		"policy.context_stop":            "comando interrompido antes do envio ao provedor (--show-context)",
+		"policy.denied_summary":          "%d arquivo(s) não enviado(s) por causa da política: %s",
+		"policy.file_denied":             "o arquivo %s não pode ser enviado ao provedor de IA por causa da política",
+		"policy.no_files":                "Nenhum arquivo seria enviado para %s.",
+		"policy.files_header":            "Arquivos que seriam enviados para %s:",
+		"policy.denied_header":           "Arquivos bloqueados pela política:",
+		"dryrun.prompt_header":           "=== --dry-run: prompt para %s / %s (~%d tokens, custo estimado de entrada: US$ %.4f) ===",
+		"dryrun.prompt_footer":           "=== fim do prompt ===",
+		"dryrun.placeholder":             "[--dry-run: resposta do provedor não gerada]",
+		"dryrun.no_actions":              "--dry-run: nenhuma alteração seria feita.",
+		"dryrun.actions_header":          "--dry-run: nenhuma alteração foi feita. Ações que seriam executadas:",
+		"dryrun.write_file":              "gravar o arquivo %s",
+		"dryrun.create_branch":           "criar e trocar para a branch %s",
+		"dryrun.clipboard":               "copiar a descrição para a área de transferência",
+		"dryrun.create_issue":            "criar uma issue do tipo %v no projeto Jira %s: %s",
+		"dryrun.create_pr":               "criar o PR '%s' de %s para %s",
+		"mock.script_read_error":         "erro ao ler o roteiro do provedor mock %s: %v",
+		"mock.script_parse_error":        "erro ao interpretar o roteiro do provedor mock %s: %v",
+		"mock.invalid_match":             "expressão inválida no roteiro do provedor mock %s: %v",
+		"mock.no_rule":                   "nenhuma regra do roteiro %s corresponde ao prompt",
+		"fixture.not_found":              "fixture não encontrada: %s (grave-a com GOJIRA_FIXTURES_MODE=record)",
+		"fixture.read_error":             "erro ao ler a fixture %s: %v",
+		"fixture.write_error":            "erro ao gravar a fixture %s: %v",
+		"fixture.invalid_mode":           "modo de fixture inválido: %s (use record ou replay)",
+		"fixture.invalid_provider":       "provedor inválido para gravação de fixtures: %s",
+		"ai.structured_missing_field":    "campo obrigatório ausente: %s",
+		"ai.structured_unknown_field":    "campo não previsto no schema: %s",
+		"ai.structured_wrong_type":       "tipo inválido em %s: esperado %s",
+		"ai.structured_retry":            "⚠️ Resposta fora do formato esperado (%v), solicitando novamente...",
+		"ai.structured_invalid":          "a resposta do provedor %s não segue o formato esperado: %v",
+		"config.invalid_max_tokens":      "limite de tokens inválido: %d",
+		"config.max_tokens":              "- Limite de tokens das respostas: %d",
+		"chat.welcome":                   "Conversa iniciada. Use /help para ver os comandos e /exit para sair.",
+		"chat.help":                      "Comandos: /file <caminho>, /diff, /issue <ID>, /branch, /help, /exit",
+		"chat.attached":                  "📎 Contexto anexado (%d item(ns) para a próxima pergunta)",
+		"chat.command_error":             "❌ %v",
+		"chat.answer_error":              "❌ Erro ao obter resposta: %v",
+		"chat.unknown_command":           "comando desconhecido: %s (use /help)",
+		"chat.file_usage":                "uso: /file <caminho>",
+		"chat.issue_usage":               "uso: /issue <ID da tarefa>",
+		"chat.file_read_error":           "erro ao ler o arquivo %s: %v",
+		"chat.file_unsupported":          "o arquivo %s é binário ou grande demais para ser enviado",
+		"chat.branch_error":              "erro ao obter o estado da branch: %v",
+		"chat.save_error":                "erro ao salvar a conversa: %v",
+		"chat.saved":                     "Conversa salva em %s",
+		"explain.symbol_and_lines":       "use --symbol ou --start/--end, não ambos",
+		"explain.symbol_found":           "Símbolo %s encontrado em %s (linhas %d-%d)",
+		"explain.references_found":       "Incluindo %d definição(ões) referenciada(s) como contexto",
+		"source.read_error":              "erro ao ler %s: %v",
+		"source.parse_error":             "erro ao analisar %s: %v",
+		"source.symbol_not_found":        "símbolo %s não encontrado em %s",
+		"source.context_added":           "Incluindo o contexto do pacote (~%d tokens)",
+		"test.running":                   "Executando os testes com %s (tentativa %d de %d)...",
+		"test.fixing":                    "⚠️ %d teste(s) falharam ou o código não compilou, pedindo correção à IA...",
+		"test.restore_error":             "erro ao restaurar o arquivo de teste original: %v",
+		"test.report_passed":             "✅ Os testes passaram após %d tentativa(s)",
+		"test.report_restored":           "❌ Nenhuma versão passou em %d tentativa(s); %s foi restaurado",
+		"test.report_summary":            "Casos: %d passando, %d falhando, %d removido(s)",
+		"testrun.timeout":                "a execução de %s excedeu o tempo limite de %v",
+		"testrun.exec_error":             "erro ao executar %s: %v",
+		"test.invalid_attempts":          "número de tentativas inválido: %d (mínimo 1)",
+		"test.uncovered_go_only":         "--uncovered só está disponível para arquivos Go",
+		"test.measuring_coverage":        "Medindo a cobertura atual do pacote...",
+		"test.coverage_error":            "erro ao medir a cobertura: %v",
+		"test.coverage_before":           "Cobertura atual: %.1f%% (%d função(ões) com trechos sem cobertura)",
+		"test.fully_covered":             "✅ Todas as funções de %s já estão cobertas por testes",
+		"test.coverage_after":            "Cobertura: %.1f%% → %.1f%%",
+		"test.coverage_after_error":      "⚠️ Não foi possível medir a cobertura após a geração: %v",
+		"testrun.coverage_error":         "perfil de cobertura não gerado: %v",
+		"source.merge_existing_error":    "não foi possível analisar o arquivo de teste existente: %v",
+		"source.merge_generated_error":   "não foi possível mesclar os testes gerados: %v",
+		"git.diff_text_error":            "erro ao comparar as versões do arquivo: %v",
+		"test.merge_diff":                "Alterações em %s:",
+		"test.merge_summary":             "Mesclagem: %d teste(s) adicionado(s), %d substituído(s)",
+		"test.merge_collisions":          "⚠️ Já existem e foram mantidos: %s (use --replace para substituí-los)",
+		"test.merge_unchanged":           "Nenhum teste novo para adicionar a %s",
+		"git.remote_error":               "erro ao obter a URL do remoto '%s'",
+		"git.remote_parse_error":         "URL de remoto não reconhecida: %s",
+		"codehost.request_error":         "erro ao acessar a API do %s: %v",
+		"codehost.api_error":             "a API do %s recusou %s %s (%d): %s",
+		"codehost.invalid_response":      "resposta inválida da API do %s: %v",
+		"codehost.milestone_not_found":   "milestone '%s' não encontrado entre os abertos",
+		"config.github_api_url":          "- API do GitHub: %s",
+		"config.github_token_set":        "- Token do GitHub: Configurado",
+		"config.github_token_unset":      "- Token do GitHub: Não configurado (usa GITHUB_TOKEN ou GH_TOKEN, se definidas)",
+		"pr.invalid_remote":              "repositório remoto inválido: %s (use o formato owner/repo)",
+		"pr.creating_api":                "Criando PR pela API do %s em %s...",
+		"pr.created":                     "✅ PR #%d criado: %s",
+		"pr.updated":                     "✅ PR #%d já existia e foi atualizado: %s",
+		"pr.api_error":                   "erro ao criar o PR: %w",
+		"codehost.unknown":               "plataforma desconhecida: %s (use github, gitlab, gitea ou bitbucket)",
+		"codehost.user_not_found":        "usuário '%s' não encontrado",
+		"codehost.label_not_found":       "label '%s' não encontrada no repositório",
+		"codehost.bitbucket_unsupported": "⚠️ O Bitbucket Server não tem labels, responsáveis nem milestones; essas opções foram ignoradas",
+		"pr.missing_token":               "o repositório está no %s, mas não há token configurado: use gojira config --code-host-token (ou --github-token) ou a variável %s",
+		"config.invalid_code_host":       "plataforma inválida: %s (use github, gitlab, gitea ou bitbucket)",
+		"config.code_host":               "- Plataforma dos PRs: %s",
+		"config.code_host_detect":        "detectada pelo remoto origin",
+		"config.code_host_url":           "- URL da plataforma: %s",
+		"config.code_host_token_set":     "- Token da plataforma: Configurado",
+		"config.code_host_token_unset":   "- Token da plataforma: Não configurado",
+		"pr.template_not_found":          "modelo de PR '%s' não encontrado",
+		"pr.template_read_error":         "erro ao ler o modelo de PR %s: %v",
+		"pr.template_using":              "Usando o modelo de descrição %s",
+		"pr.template_multiple":           "O repositório tem vários modelos de PR (%s); usando %s. Escolha outro com --template",
+		"pr.template_choose":             "O repositório tem vários modelos de PR:",
+		"pr.template_prompt":             "Qual modelo usar? [1]: ",
+		"git.default_branch_error":       "não foi possível identificar a branch padrão; informe a base com --base ou git config gojira.baseBranch <branch>",
+		"git.merge_base_error":           "erro ao calcular o merge-base entre %s e %s; verifique se as duas referências existem",
+		"summary.using_base":             "Comparando com %s (ponto de divergência de %s)",
+		"jira.transition_not_found":      "transição '%s' não disponível para %s (disponíveis: %s)",
+		"jira.request_status_error":      "erro na requisição %s %s ao Jira: %d",
+		"pr.jira_issue":                  "Issue do Jira: %s - %s",
+		"pr.jira_fetch_warning":          "⚠️ Não foi possível buscar a issue %s no Jira; o PR seguirá sem o link: %v",
+		"pr.jira_no_url":                 "⚠️ A URL do PR não foi identificada; o link não foi adicionado à issue %s",
+		"pr.jira_link_warning":           "⚠️ Não foi possível adicionar o link do PR à issue %s: %v",
+		"pr.jira_linked":                 "Link do PR adicionado à issue %s",
+		"pr.jira_transition_warning":     "⚠️ Não foi possível mover a issue %s: %v",
+		"pr.jira_transitioned":           "Issue %s movida para %s",
+		"pr.jira_already_in_status":      "A issue %s já está em %s",
+		"dryrun.jira_link":               "adicionar à issue %s do Jira um link para o PR",
+		"dryrun.jira_transition":         "mover a issue %s do Jira para %s",
+		"git.range_diff_error":           "erro ao listar os arquivos alterados entre %s e %s",
+		"pr.summarizing_files":           "Resumindo %d arquivo(s), até %d ao mesmo tempo, com orçamento de %d tokens...",
+		"pr.summarizing_file":            "Resumindo %s...",
+		"pr.file_summary_error":          "erro ao resumir %s: %w",
+		"pr.invalid_budget":              "--token-budget deve ser maior que zero",
+		"git.log_range_error":            "erro ao obter os commits de %s",
+		"git.no_tag_error":               "nenhuma tag encontrada a partir de %s",
+		"release.no_commits":             "nenhum commit no formato Conventional Commits em %s",
+		"release.ignored_commits":        "%d commit(s) fora do formato Conventional Commits foram ignorados",
+		"release.version":                "Próxima versão sugerida: %s (%s a partir de %s)",
+		"release.first_version":          "Nenhuma versão anterior encontrada; versão sugerida: %s",
+		"release.ticket_warning":         "⚠️ Não foi possível buscar o ticket %s no Jira: %v",
+		"release.generating":             "Gerando as notas de versão...",
+		"release.error":                  "erro ao gerar as notas de versão: %w",
+		"release.changelog_read_error":   "erro ao ler %s: %v",
+		"release.changelog_write_error":  "erro ao gravar %s: %v",
+		"release.changelog_updated":      "%s atualizado com a versão %s",
+		"lint.header_format":             "a primeira linha deve seguir o formato 'type: [TICKET] Mensagem'",
+		"lint.type":                      "tipo '%s' não permitido; use um de: %s",
+		"lint.ticket_format":             "ticket '%s' fora do padrão [A-Z]{2,}-\\d+",
+		"lint.ticket_required":           "a primeira linha deve trazer o ticket entre colchetes, como [ABC-123]",
+		"lint.subject_empty":             "a mensagem após o tipo e o ticket está vazia",
+		"lint.header_length":             "a primeira linha tem %d caracteres; o máximo é %d",
+		"lint.body_blank_line":           "deixe uma linha em branco entre a primeira linha e o corpo",
+		"lint.body_bullets":              "o corpo deve ser uma lista de itens iniciados por '- ': %s",
+		"lint.message_file_exclusive":    "--message-file não pode ser usado com um intervalo ou com --fix",
+		"lint.no_commits":                "Nenhum commit em %s.",
+		"lint.failed":                    "%d de %d commit(s) fora do padrão de mensagem",
+		"lint.skipped":                   "(gerado pelo Git, ignorado)",
+		"lint.fix_head":                  "--fix só reescreve commits até o HEAD; use um intervalo que termine no HEAD",
+		"lint.fix_pushed":                "⚠️ O commit %s já foi enviado e não será reescrito",
+		"lint.fixing":                    "Reescrevendo a mensagem do commit %s...",
+		"lint.fix_error":                 "erro ao reescrever a mensagem do commit %s: %w",
+		"lint.fix_invalid":               "⚠️ A mensagem sugerida para %s ainda está fora do padrão (%s); o commit foi mantido",
+		"lint.fixed":                     "%d mensagem(ns) reescrita(s); o HEAD agora é %s",
+		"lint.message_read_error":        "erro ao ler a mensagem em %s: %v",
+		"lint.message_invalid":           "A mensagem de commit está fora do padrão: %s",
+		"lint.message_hint":              "Formato esperado: type: [TICKET] Mensagem, uma linha em branco e itens iniciados por '- '. Use gojira commit para gerar a mensagem.",
+		"lint.message_failed":            "mensagem de commit fora do padrão",
+		"lint.hook_exists":               "já existe um hook em %s que não foi instalado pelo gojira; remova-o ou integre a chamada manualmente",
+		"lint.hook_error":                "erro ao instalar o hook em %s: %v",
+		"lint.hook_installed":            "Hook commit-msg instalado em %s",
+		"dryrun.rewrite_commits":         "reescrever a mensagem de %d commit(s) e atualizar a branch atual",
+		"git.ref_error":                  "referência '%s' não encontrada",
+		"git.rewrite_error":              "erro ao reescrever o commit %s: %v",
+		"git.rewrite_merge":              "o commit %s é um merge; a reescrita só funciona em históricos lineares",
+		"git.rewrite_not_ancestor":       "os commits a reescrever não estão no histórico do HEAD",
+		"history.request_error":          "erro ao ler a requisição da geração %s: %w",
+		"testrun.not_installed":          "%s não encontrado",
+		"testrun.jest_missing":           "Jest não encontrado em node_modules; instale-o no projeto com npm install --save-dev jest",
+		"test.verify_warning":            "⚠️ --verify executa na sua máquina o código de teste gerado pela IA, com %s",
+		"test.verify_unavailable":        "⚠️ Os testes serão gravados sem verificação: %v",
+		"source.merge_package_mismatch":  "o código gerado é do pacote %s, mas o arquivo de teste existente é do pacote %s",
+		"test.read_existing_error":       "erro ao ler o arquivo de teste existente %s: %w",
+		"test.existing_denied":           "o arquivo de teste %s já existe e não pode ser enviado ao provedor de IA por causa da política, então os testes não podem ser integrados a ele; use --output-file para gravá-los em outro arquivo",
+		"test.redacted_output":           "a resposta do provedor contém marcadores de redação ([REDACTED:...]) que não puderam ser restaurados; %s não foi gravado",
+		"pr.untrusted_host":              "o host %s parece ser do %s, mas não é o host público da plataforma, e o token só é enviado a ele com a URL configurada: use gojira config --code-host-url (ou --github-api-url), ou instale o gh ou o glab",
+		"pr.section_breaking":            "Breaking changes",
+		"pr.section_migrations":          "Migrações",
+		"pr.section_config":              "Mudanças de configuração",
+	},
+	English: {
+		"lang.unsupported":               "Warning: unsupported language %q. Use pt or en.",
+		"config.home_error":              "Error getting home directory: %v",
+		"config.read_error":              "error reading configuration file: %w",
+		"config.parse_error":             "error parsing configuration file: %w",
+		"config.serialize_error":         "error serializing configuration: %w",
+		"config.write_error":             "error writing configuration file: %w",
+		"env.file_missing":               "Warning: .env file not found. Checking system environment variables...",
+		"env.var_missing":                "Warning: variable %s is not set.",
+		"config.load_error":              "error loading configuration: %w",
+		"config.save_error":              "error saving configuration: %w",
+		"config.invalid_language":        "unsupported language %q. Use pt or en",
+		"config.updated":                 "Configuration updated successfully!",
+		"config.current":                 "Current configuration:",
+		"config.provider":                "- AI provider: %s",
+		"config.model_default":           "- AI model: %s (default)",
+		"config.model":                   "- AI model: %s",
+		"config.models_available":        "- Available models:",
+		"config.jira_url":                "- Jira URL: %s",
+		"config.jira_url_unset":          "- Jira URL: Not configured",
+		"config.jira_project":            "- Default Jira project: %s",
+		"config.jira_project_unset":      "- Default Jira project: Not configured",
+		"config.jira_token_set":          "- Jira token: Configured",
+		"config.jira_token_unset":        "- Jira token: Not configured",
+		"config.language":                "- Generated content language: %s",
+		"config.language_unset":          "- Generated content language: per-command default",
+		"config.ui_language":             "- CLI language: %s",
+		"config.providers_available":     "Available AI providers:",
+		"config.provider_models":         "  Available models:",
+		"config.provider_model_default":  "  * %s (default)",
+		"git.not_repo":                   "the current directory is not a Git repository",
+		"git.not_identified":             "the current directory was not identified as a Git repository",
+		"git.branch_error":               "error getting the branch name",
+		"git.diff_cached_error":          "error running git diff --cached",
+		"git.no_staged":                  "no staged files found",
+		"git.staged_detected":            "Detected files (staged): %v\n",
+		"git.file_diff_error":            "error getting diff for file %s",
+		"git.no_staged_diff":             "no differences found in staged files",
+		"git.gitignore_read_error":       "Error reading .gitignore: %v",
+		"git.pattern_error":              "Error processing pattern %s: %v",
+		"commit.repo_detected":           "Git repository detected. Preparing diffs...",
+		"commit.suggested":               "\nSuggested commit message:",
+		"analysis.files_error":           "error getting project files: %w",
+		"analysis.no_files":              "no relevant files found in the project",
+		"analysis.read_error":            "error reading project files: %w",
+		"analysis.log_error":             "error writing analysis log: %w",
+		"analysis.log_warning":           "Error writing analysis log: %v",
+		"analysis.response_error":        "error getting response from the AI provider: %w",
+		"analysis.skip_csproj":           "skipping .csproj file: %s",
+		"analysis.skip_bin":              "skipping bin/ or obj/ directory: %s",
+		"analysis.skip_ide":              "skipping .idea/ or .vscode/ directory: %s",
+		"analysis.file_read_error":       "error reading file %s: %v",
+		"analysis.skip_binary":           "skipping binary or oversized file: %s",
+		"analysis.log_dir_error":         "error creating log directory: %w",
+		"analysis.log_file_error":        "error creating log file: %w",
+		"analysis.log_close_error":       "error closing log file: %v",
+		"analysis.wd_error":              "Error getting current directory: %v",
+		"analysis.unknown_project":       "Unknown Project",
+		"readme.repo_check_error":        "error checking Git repository: %v",
+		"readme.not_repo":                "directory is not a Git repository",
+		"readme.tree_error":              "error running tree command: %v",
+		"readme.files_error":             "error getting file details: %v",
+		"readme.analysis_files_error":    "error getting analysis file details: %v",
+		"readme.save_error":              "error saving README.md: %v",
+		"readme.generated":               "README.md generated successfully!",
+		"input.issue_key":                "Enter the issue key (e.g. ABC-123):",
+		"input.issue_key_error":          "error reading the issue key: %w",
+		"input.branch_name":              "Enter the branch name (without the prefix):",
+		"input.branch_name_error":        "error reading the branch name: %w",
+		"input.yes":                      "y",
+		"dev.branch_create_error":        "error creating the branch: %w",
+		"dev.branch_created":             "Branch %s created successfully!",
+		"dev.issue_fetch_warning":        "Could not get details for issue %s: %v",
+		"dev.continue_anyway":            "Continue anyway? (y/n)",
+		"dev.cancelled":                  "operation cancelled by the user",
+		"dev.task":                       "Task: %s - %s",
+		"dev.suggested_branch":           "Suggested branch name: %s/%s-%s",
+		"dev.use_name":                   "Use this name? (y/n)",
+		"dev.branch_name_custom":         "Enter the desired branch name (without the prefix and the issue):",
+		"dev.current_branch_error":       "error getting the current branch name: %w",
+		"dev.issue_fetch_error":          "could not get details for issue %s: %w",
+		"dev.checklist_error":            "error generating checklist: %w",
+		"dev.checklist_save_error":       "error saving checklist: %w",
+		"dev.checklist_saved":            "Checklist generated and saved to %s",
+		"dev.checklist":                  "\nChecklist:",
+		"jira.invalid_type":              "invalid task type. Use EPICO, BUG or TASK",
+		"jira.empty_title":               "the task title cannot be empty",
+		"jira.generated":                 "\nGenerated description:",
+		"clipboard.error":                "could not copy to the clipboard",
+		"jira.copied":                    "\nThe description was copied to the clipboard!",
+		"jira.create_warning":            "\nWarning: could not create the task in Jira: %v",
+		"jira.created":                   "\nTask created in Jira successfully: %s",
+		"kanban.jira_incomplete":         "incomplete Jira configuration. Use 'gojira config' to set it up",
+		"kanban.no_project":              "project not specified. Use --project or configure a default project",
+		"kanban.fetching":                "Fetching tasks for project %s...",
+		"kanban.fetch_error":             "error fetching tasks: %w",
+		"kanban.no_tasks":                "No tasks",
+		"git.must_be_repo":               "this command must be run inside a Git repository",
+		"pr.generating_title":            "Generating PR title...",
+		"pr.title_error":                 "error generating PR title: %w",
+		"pr.title_generated":             "Generated title: %s",
+		"pr.generating_description":      "Generating PR description...",
+		"pr.description_error":           "error generating PR description: %w",
+		"pr.description_generated":       "Description generated.",
+		"pr.description_save_error":      "error saving the PR description: %w",
+		"pr.no_cli":                      "neither 'gh' (GitHub CLI) nor 'glab' (GitLab CLI) was found. Configure a GitHub token (gojira config --github-token or GITHUB_TOKEN) or install one of them to create PRs",
+		"pr.creating":                    "Creating PR using %s...",
+		"pr.log_error":                   "error getting commit log: %w",
+		"pr.title_ai_error":              "error generating title with AI: %w",
+		"pr.diff_stat_error":             "error getting diff stat: %w",
+		"pr.commits_error":               "error getting commits: %w",
+		"pr.description_ai_error":        "error generating description with AI: %w",
+		"summary.files_error":            "error getting the list of changed files: %w",
+		"summary.no_changes":             "no changes found since %s",
+		"summary.diff_warning":           "Warning: error getting diff for %s: %v",
+		"summary.generating":             "Generating change summary...",
+		"summary.error":                  "error generating summary: %w",
+		"summary.save_error":             "error saving the summary: %w",
+		"summary.saved":                  "Summary saved to %s",
+		"standup.collect_error":          "error collecting activities: %w",
+		"standup.generating":             "Generating standup report...",
+		"standup.error":                  "error generating report: %w",
+		"standup.save_error":             "error saving the report: %w",
+		"standup.saved":                  "Report saved to %s",
+		"explain.no_file":                "a file path must be provided",
+		"explain.file_missing":           "file %s does not exist",
+		"explain.read_error":             "error reading the file: %w",
+		"explain.error":                  "error generating explanation: %w",
+		"explain.save_error":             "error saving the explanation: %w",
+		"explain.saved":                  "Explanation saved to %s",
+		"test.no_source":                 "a source file path must be provided",
+		"test.source_missing":            "source file %s does not exist",
+		"test.read_error":                "error reading the source file: %w",
+		"test.generating":                "Generating tests...",
+		"test.error":                     "error generating tests: %w",
+		"test.save_error":                "error saving the tests: %w",
+		"test.saved":                     "Tests generated successfully and saved to %s",
+		"jira.not_configured":            "Jira URL or authentication token not configured",
+		"jira.fetch_status_error":        "error fetching task from Jira: %d",
+		"jira.invalid_response":          "invalid Jira response format",
+		"jira.invalid_issue_type":        "invalid Jira issue type format",
+		"jira.invalid_project":           "invalid Jira project format",
+		"jira.no_project":                "Jira project not specified",
+		"jira.create_status_error":       "error creating task in Jira: %d",
+		"jira.created_key_error":         "error getting the key of the created task",
+		"ai.missing_key":                 "%s not provided",
+		"ai.api_error":                   "%s API call failed (%d): %s",
+		"ai.unexpected_response":         "unexpected response from the %s API",
+		"output.invalid_format":          "unsupported output format %q. Use text or json",
+		"config.history_retention":       "- History retention: %d days, %d generations (0 = no limit)",
+		"history.dir_error":              "error creating history directory: %w",
+		"history.serialize_error":        "error serializing generation: %w",
+		"history.write_error":            "error writing generation to history: %w",
+		"history.read_error":             "error reading history: %w",
+		"history.ambiguous_id":           "ID %q matches more than one generation",
+		"history.not_found":              "generation %q not found in history",
+		"history.no_retention":           "no retention set. Use --days, --keep or configure it with 'gojira config --history-days/--history-max'",
+		"history.remove_error":           "error removing generation %s: %w",
+		"history.record_warning":         "Warning: could not record the generation in history: %v",
+		"history.empty":                  "No generations in history.",
+		"history.show_header":            "Generation %s (%s) at %s",
+		"history.show_provider":          "Provider: %s / %s (%s)",
+		"history.show_prompt":            "\n=== Prompt ===",
+		"history.show_response":          "\n=== Response ===",
+		"history.unknown_provider":       "unknown AI provider %q",
+		"history.rerunning":              "Re-running generation %s...",
+		"history.original_response":      "=== Original response (%s / %s) ===",
+		"history.new_response":           "\n=== New response (%s / %s) ===",
+		"history.negative_retention":     "retention values cannot be negative",
+		"history.pruned":                 "%d generations removed from history.",
+		"cache.dir_error":                "error creating cache directory: %v",
+		"cache.write_error":              "error writing response to cache: %v",
+		"cache.read_error":               "error reading cache: %v",
+		"cache.remove_error":             "error removing cached response: %v",
+		"cache.hit":                      "Using cached response (use --no-cache to query the provider again).",
+		"cache.write_warning":            "Warning: could not write response to cache: %v",
+		"cache.cleared":                  "%d responses removed from cache.",
+		"config.cache":                   "- Cache: enabled, TTL %d hours, max %d MB (0 = default)",
+		"config.cache_disabled":          "- Cache: disabled",
+		"usage.dir_error":                "error creating usage directory: %v",
+		"usage.write_error":              "error recording usage: %v",
+		"usage.read_error":               "error reading usage: %v",
+		"usage.record_warning":           "Warning: could not record token usage: %v",
+		"usage.budget_check_warning":     "Warning: could not check the monthly budget: %v",
+		"usage.budget_warning":           "Warning: the monthly budget has been exceeded (US$ %.2f of US$ %.2f).",
+		"usage.budget_exceeded":          "monthly budget exceeded (US$ %.2f of US$ %.2f); adjust it with gojira config --budget or --budget-action warn",
+		"usage.summary":                  "Tokens: %d input, %d output (estimated cost: US$ %.4f)",
+		"usage.invalid_group":            "invalid grouping: %s (use day, command or model)",
+		"usage.invalid_month":            "invalid month: %s (use the YYYY-MM format)",
+		"usage.empty":                    "No usage recorded in %s.",
+		"usage.header":                   "Usage for %s:",
+		"usage.column_day":               "Day",
+		"usage.column_command":           "Command",
+		"usage.column_model":             "Model",
+		"usage.column_calls":             "Calls",
+		"usage.column_input":             "Input",
+		"usage.column_output":            "Output",
+		"usage.column_cost":              "US$",
+		"usage.total":                    "Total",
+		"usage.budget_status":            "Budget: US$ %.2f of US$ %.2f (%.0f%%)",
+		"config.invalid_budget_action":   "invalid budget action: %s (use warn or block)",
+		"config.invalid_price":           "invalid price: %s (use model=input,output, in dollars per million tokens)",
+		"config.budget":                  "- Monthly budget: US$ %.2f (when exceeded: %s)",
+		"config.budget_unset":            "- Monthly budget: no limit",
+		"config.price":                   "- Price for %s: US$ %.2f input / US$ %.2f output per million tokens",
+		"redact.invalid_pattern":         "invalid personal data pattern %s: %v",
+		"redact.masked":                  "Sensitive data masked before sending to the provider: %s",
+		"redact.strict_abort":            "sensitive data found in the prompt: %s; nothing was sent to the provider (strict redaction mode)",
+		"config.invalid_redaction":       "invalid redaction mode: %s (use mask, strict or off)",
+		"config.invalid_pii_pattern":     "invalid pattern: %s (use name=expression)",
+		"config.redaction":               "- Sensitive data redaction: %s",
+		"config.pii_pattern":             "- Personal data pattern %s: %s",
+		"policy.read_error":              "error reading policy file: %v",
+		"policy.parse_error":             "error parsing policy file %s: %v",
+		"policy.context_stop":            "command stopped before sending to the provider (--show-context)",
+		"policy.denied_summary":          "%d file(s) not sent because of the policy: %s",
+		"policy.file_denied":             "file %s cannot be sent to the AI provider because of the policy",
+		"policy.no_files":                "No files would be sent to %s.",
+		"policy.files_header":            "Files that would be sent to %s:",
+		"policy.denied_header":           "Files blocked by the policy:",
+		"dryrun.prompt_header":           "=== --dry-run: prompt for %s / %s (~%d tokens, estimated input cost: US$ %.4f) ===",
+		"dryrun.prompt_footer":           "=== end of prompt ===",
+		"dryrun.placeholder":             "[--dry-run: provider response not generated]",
+		"dryrun.no_actions":              "--dry-run: no changes would be made.",
+		"dryrun.actions_header":          "--dry-run: no changes were made. Actions that would have run:",
+		"dryrun.write_file":              "write file %s",
+		"dryrun.create_branch":           "create and switch to branch %s",
+		"dryrun.clipboard":               "copy the description to the clipboard",
+		"dryrun.create_issue":            "create a %v issue in Jira project %s: %s",
+		"dryrun.create_pr":               "create PR '%s' from %s into %s",
+		"mock.script_read_error":         "error reading mock provider script %s: %v",
+		"mock.script_parse_error":        "error parsing mock provider script %s: %v",
+		"mock.invalid_match":             "invalid expression in mock provider script %s: %v",
+		"mock.no_rule":                   "no rule in script %s matches the prompt",
+		"fixture.not_found":              "fixture not found: %s (record it with GOJIRA_FIXTURES_MODE=record)",
+		"fixture.read_error":             "error reading fixture %s: %v",
+		"fixture.write_error":            "error writing fixture %s: %v",
+		"fixture.invalid_mode":           "invalid fixture mode: %s (use record or replay)",
+		"fixture.invalid_provider":       "invalid provider for fixture recording: %s",
+		"ai.structured_missing_field":    "missing required field: %s",
+		"ai.structured_unknown_field":    "field not in schema: %s",
+		"ai.structured_wrong_type":       "wrong type at %s: expected %s",
+		"ai.structured_retry":            "⚠️ Response does not match the expected format (%v), asking again...",
+		"ai.structured_invalid":          "the response from provider %s does not match the expected format: %v",
+		"config.invalid_max_tokens":      "invalid token limit: %d",
+		"config.max_tokens":              "- Response token limit: %d",
+		"chat.welcome":                   "Chat started. Use /help to see the commands and /exit to quit.",
+		"chat.help":                      "Commands: /file <path>, /diff, /issue <ID>, /branch, /help, /exit",
+		"chat.attached":                  "📎 Context attached (%d item(s) for the next question)",
+		"chat.command_error":             "❌ %v",
+		"chat.answer_error":              "❌ Error getting answer: %v",
+		"chat.unknown_command":           "unknown command: %s (use /help)",
+		"chat.file_usage":                "usage: /file <path>",
+		"chat.issue_usage":               "usage: /issue <issue ID>",
+		"chat.file_read_error":           "error reading file %s: %v",
+		"chat.file_unsupported":          "file %s is binary or too large to be sent",
+		"chat.branch_error":              "error getting the branch state: %v",
+		"chat.save_error":                "error saving the chat: %v",
+		"chat.saved":                     "Chat saved to %s",
+		"explain.symbol_and_lines":       "use --symbol or --start/--end, not both",
+		"explain.symbol_found":           "Symbol %s found in %s (lines %d-%d)",
+		"explain.references_found":       "Including %d referenced definition(s) as context",
+		"source.read_error":              "error reading %s: %v",
+		"source.parse_error":             "error parsing %s: %v",
+		"source.symbol_not_found":        "symbol %s not found in %s",
+		"source.context_added":           "Including package context (~%d tokens)",
+		"test.running":                   "Running the tests with %s (attempt %d of %d)...",
+		"test.fixing":                    "⚠️ %d test(s) failed or the code did not compile, asking the AI for a fix...",
+		"test.restore_error":             "error restoring the original test file: %v",
+		"test.report_passed":             "✅ Tests passed after %d attempt(s)",
+		"test.report_restored":           "❌ No version passed in %d attempt(s); %s was restored",
+		"test.report_summary":            "Cases: %d passing, %d failing, %d removed",
+		"testrun.timeout":                "%s exceeded the %v timeout",
+		"testrun.exec_error":             "error running %s: %v",
+		"test.invalid_attempts":          "invalid number of attempts: %d (minimum 1)",
+		"test.uncovered_go_only":         "--uncovered is only available for Go files",
+		"test.measuring_coverage":        "Measuring the current package coverage...",
+		"test.coverage_error":            "error measuring coverage: %v",
+		"test.coverage_before":           "Current coverage: %.1f%% (%d function(s) with uncovered code)",
+		"test.fully_covered":             "✅ All functions in %s are already covered by tests",
+		"test.coverage_after":            "Coverage: %.1f%% → %.1f%%",
+		"test.coverage_after_error":      "⚠️ Could not measure coverage after generation: %v",
+		"testrun.coverage_error":         "coverage profile not generated: %v",
+		"source.merge_existing_error":    "could not parse the existing test file: %v",
+		"source.merge_generated_error":   "could not merge the generated tests: %v",
+		"git.diff_text_error":            "error comparing file versions: %v",
+		"test.merge_diff":                "Changes to %s:",
+		"test.merge_summary":             "Merge: %d test(s) added, %d replaced",
+		"test.merge_collisions":          "⚠️ Already exist and were kept: %s (use --replace to replace them)",
+		"test.merge_unchanged":           "No new tests to add to %s",
+		"git.remote_error":               "error getting the URL of remote '%s'",
+		"git.remote_parse_error":         "unrecognized remote URL: %s",
+		"codehost.request_error":         "error calling the %s API: %v",
+		"codehost.api_error":             "the %s API rejected %s %s (%d): %s",
+		"codehost.invalid_response":      "invalid response from the %s API: %v",
+		"codehost.milestone_not_found":   "milestone '%s' not found among open milestones",
+		"config.github_api_url":          "- GitHub API: %s",
+		"config.github_token_set":        "- GitHub token: Configured",
+		"config.github_token_unset":      "- GitHub token: Not configured (uses GITHUB_TOKEN or GH_TOKEN when set)",
+		"pr.invalid_remote":              "invalid remote repository: %s (use the owner/repo format)",
+		"pr.creating_api":                "Creating PR through the %s API in %s...",
+		"pr.created":                     "✅ PR #%d created: %s",
+		"pr.updated":                     "✅ PR #%d already existed and was updated: %s",
+		"pr.api_error":                   "error creating the PR: %w",
+		"codehost.unknown":               "unknown code host: %s (use github, gitlab, gitea or bitbucket)",
+		"codehost.user_not_found":        "user '%s' not found",
+		"codehost.label_not_found":       "label '%s' not found in the repository",
+		"codehost.bitbucket_unsupported": "⚠️ Bitbucket Server has no labels, assignees or milestones; those options were ignored",
+		"pr.missing_token":               "the repository is on %s, but no token is configured: use gojira config --code-host-token (or --github-token) or the %s variable",
+		"config.invalid_code_host":       "invalid code host: %s (use github, gitlab, gitea or bitbucket)",
+		"config.code_host":               "- PR code host: %s",
+		"config.code_host_detect":        "detected from the origin remote",
+		"config.code_host_url":           "- Code host URL: %s",
+		"config.code_host_token_set":     "- Code host token: Configured",
+		"config.code_host_token_unset":   "- Code host token: Not configured",
+		"pr.template_not_found":          "PR template '%s' not found",
+		"pr.template_read_error":         "error reading PR template %s: %v",
+		"pr.template_using":              "Using description template %s",
+		"pr.template_multiple":           "The repository has several PR templates (%s); using %s. Choose another with --template",
+		"pr.template_choose":             "The repository has several PR templates:",
+		"pr.template_prompt":             "Which template should be used? [1]: ",
+		"git.default_branch_error":       "could not determine the default branch; pass the base with --base or git config gojira.baseBranch <branch>",
+		"git.merge_base_error":           "error computing the merge base of %s and %s; check that both refs exist",
+		"summary.using_base":             "Comparing against %s (where it diverged from %s)",
+		"jira.transition_not_found":      "transition '%s' is not available for %s (available: %s)",
+		"jira.request_status_error":      "Jira request %s %s failed: %d",
+		"pr.jira_issue":                  "Jira issue: %s - %s",
+		"pr.jira_fetch_warning":          "⚠️ Could not fetch Jira issue %s; the PR will be created without the link: %v",
+		"pr.jira_no_url":                 "⚠️ The PR URL could not be determined; no link was added to issue %s",
+		"pr.jira_link_warning":           "⚠️ Could not add the PR link to issue %s: %v",
+		"pr.jira_linked":                 "PR link added to issue %s",
+		"pr.jira_transition_warning":     "⚠️ Could not transition issue %s: %v",
+		"pr.jira_transitioned":           "Issue %s moved to %s",
+		"pr.jira_already_in_status":      "Issue %s is already in %s",
+		"dryrun.jira_link":               "add a link to the PR on Jira issue %s",
+		"dryrun.jira_transition":         "transition Jira issue %s to %s",
+		"git.range_diff_error":           "error listing the files changed between %s and %s",
+		"pr.summarizing_files":           "Summarizing %d file(s), up to %d at a time, with a budget of %d tokens...",
+		"pr.summarizing_file":            "Summarizing %s...",
+		"pr.file_summary_error":          "error summarizing %s: %w",
+		"pr.invalid_budget":              "--token-budget must be greater than zero",
+		"git.log_range_error":            "error getting the commits of %s",
+		"git.no_tag_error":               "no tag found from %s",
+		"release.no_commits":             "no Conventional Commits found in %s",
+		"release.ignored_commits":        "%d commit(s) not in the Conventional Commits format were ignored",
+		"release.version":                "Suggested next version: %s (%s from %s)",
+		"release.first_version":          "No previous version found; suggested version: %s",
+		"release.ticket_warning":         "⚠️ Could not fetch ticket %s from Jira: %v",
+		"release.generating":             "Generating release notes...",
+		"release.error":                  "error generating release notes: %w",
+		"release.changelog_read_error":   "error reading %s: %v",
+		"release.changelog_write_error":  "error writing %s: %v",
+		"release.changelog_updated":      "%s updated with version %s",
+		"lint.header_format":             "the first line must follow the 'type: [TICKET] Message' format",
+		"lint.type":                      "type '%s' is not allowed; use one of: %s",
+		"lint.ticket_format":             "ticket '%s' does not match [A-Z]{2,}-\\d+",
+		"lint.ticket_required":           "the first line must include the ticket in brackets, like [ABC-123]",
+		"lint.subject_empty":             "the message after the type and ticket is empty",
+		"lint.header_length":             "the first line has %d characters; the maximum is %d",
+		"lint.body_blank_line":           "leave a blank line between the first line and the body",
+		"lint.body_bullets":              "the body must be a list of items starting with '- ': %s",
+		"lint.message_file_exclusive":    "--message-file cannot be used with a range or with --fix",
+		"lint.no_commits":                "No commits in %s.",
+		"lint.failed":                    "%d of %d commit(s) do not follow the message convention",
+		"lint.skipped":                   "(generated by Git, skipped)",
+		"lint.fix_head":                  "--fix only rewrites commits up to HEAD; use a range that ends at HEAD",
+		"lint.fix_pushed":                "⚠️ Commit %s was already pushed and will not be rewritten",
+		"lint.fixing":                    "Rewriting the message of commit %s...",
+		"lint.fix_error":                 "error rewriting the message of commit %s: %w",
+		"lint.fix_invalid":               "⚠️ The message suggested for %s still breaks the convention (%s); the commit was kept",
+		"lint.fixed":                     "%d message(s) rewritten; HEAD is now %s",
+		"lint.message_read_error":        "error reading the message in %s: %v",
+		"lint.message_invalid":           "The commit message does not follow the convention: %s",
+		"lint.message_hint":              "Expected format: type: [TICKET] Message, a blank line and items starting with '- '. Use gojira commit to generate the message.",
+		"lint.message_failed":            "commit message does not follow the convention",
+		"lint.hook_exists":               "a hook not installed by gojira already exists at %s; remove it or add the call manually",
+		"lint.hook_error":                "error installing the hook at %s: %v",
+		"lint.hook_installed":            "commit-msg hook installed at %s",
+		"dryrun.rewrite_commits":         "rewrite the message of %d commit(s) and update the current branch",
+		"git.ref_error":                  "ref '%s' not found",
+		"git.rewrite_error":              "error rewriting commit %s: %v",
+		"git.rewrite_merge":              "commit %s is a merge; rewriting only works on linear history",
+		"git.rewrite_not_ancestor":       "the commits to rewrite are not in HEAD's history",
+		"history.request_error":          "error reading the request of generation %s: %w",
+		"testrun.not_installed":          "%s not found",
+		"testrun.jest_missing":           "Jest not found in node_modules; install it in the project with npm install --save-dev jest",
+		"test.verify_warning":            "⚠️ --verify runs the AI-generated test code on your machine, with %s",
+		"test.verify_unavailable":        "⚠️ The tests will be saved without verification: %v",
+		"source.merge_package_mismatch":  "the generated code belongs to package %s, but the existing test file belongs to package %s",
+		"test.read_existing_error":       "error reading the existing test file %s: %w",
+		"test.existing_denied":           "test file %s already exists and cannot be sent to the AI provider because of the policy, so the tests cannot be integrated into it; use --output-file to save them to another file",
+		"test.redacted_output":           "the provider response contains redaction markers ([REDACTED:...]) that could not be restored; %s was not written",
+		"pr.untrusted_host":              "host %s looks like %s, but it is not the platform's public host, and the token is only sent to it when its URL is configured: use gojira config --code-host-url (or --github-api-url), or install gh or glab",
+		"pr.section_breaking":            "Breaking changes",
+		"pr.section_migrations":          "Migrations",
+		"pr.section_config":              "Configuration changes",
+	},
+}
@@ -0,0 +1,66 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Formatos de saída suportados pela CLI
+const (
+	Text = "text"
+	JSON = "json"
+)
+
+// format é o formato de saída atual
+var format = Text
+
+// SetFormat define o formato de saída, retornando false se o formato não for suportado
+func SetFormat(value string) bool {
+	switch value {
+	case Text, JSON:
+		format = value
+		return true
+	default:
+		return false
+	}
+}
+
+// IsJSON indica se a saída deve ser um objeto JSON legível por máquina
+func IsJSON() bool {
+	return format == JSON
+}
+
+// ProgressWriter retorna onde devem ser escritas mensagens de progresso e avisos.
+// No modo JSON, tudo que não é o resultado final vai para o stderr.
+func ProgressWriter() io.Writer {
+	if IsJSON() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// Progress exibe uma mensagem de progresso ou aviso
+func Progress(a ...interface{}) {
+	_, _ = fmt.Fprintln(ProgressWriter(), a...)
+}
+
+// Print exibe o conteúdo principal do comando. No modo JSON o conteúdo vai
+// no objeto de resultado, então nada é escrito aqui.
+func Print(a ...interface{}) {
+	if IsJSON() {
+		return
+	}
+	fmt.Println(a...)
+}
+
+// Emit escreve o resultado do comando como JSON no stdout quando o modo JSON está ativo
+func Emit(result interface{}) error {
+	if !IsJSON() {
+		return nil
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,301 @@
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"gojira/utils/commons"
+	"gojira/utils/i18n"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileName é o arquivo de política procurado na raiz do repositório
+const FileName = ".gojira-policy.json"
+
+// Rules representa as listas de globs permitidos e negados.
+// Um arquivo é enviado se não casar com nenhum glob de deny e, havendo allow, casar com algum deles.
+type Rules struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// Policy representa o arquivo de política, com regras padrão e regras por provedor, que se somam às padrão
+type Policy struct {
+	Rules
+	Providers map[string]Rules `json:"providers,omitempty"`
+}
+
+var (
+	// active são as regras em vigor para o provedor configurado; todas precisam permitir o arquivo
+	active []Rules
+
+	// activeProvider é o provedor para o qual as regras foram carregadas
+	activeProvider string
+
+	// included e denied registram os arquivos avaliados na execução atual
+	included []string
+	denied   []string
+
+	// showContext indica que o comando deve apenas listar o contexto que seria enviado
+	showContext bool
+
+	// reached indica que o comando chegou à fronteira com o provedor no modo --show-context
+	reached bool
+
+	// root é a raiz do repositório, a que os caminhos da política são relativos
+	root string
+)
+
+// GetPolicyFilePath retorna o arquivo de política em uso: o da raiz do repositório ou, na falta
+// dele, o global. Assim, a política vale também para comandos executados em subdiretórios.
+func GetPolicyFilePath() string {
+	path := filepath.Join(repoRoot(), FileName)
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+	return filepath.Join(commons.GetDataDir(), "policy.json")
+}
+
+// repoRoot retorna a raiz do repositório Git atual ou, fora de um repositório, o diretório atual
+func repoRoot() string {
+	if root != "" {
+		return root
+	}
+	root = workingDir()
+	if output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output(); err == nil {
+		if toplevel := strings.TrimSpace(string(output)); toplevel != "" {
+			root = resolve(toplevel)
+		}
+	}
+	return root
+}
+
+// workingDir retorna o diretório atual com os links simbólicos resolvidos, como o Git o informa
+func workingDir() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	return resolve(wd)
+}
+
+// resolve resolve os links simbólicos do caminho, mantendo-o quando não for possível
+func resolve(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
+
+// Load lê o arquivo de política; sem arquivo, retorna uma política que permite tudo
+func Load() (*Policy, error) {
+	data, err := os.ReadFile(GetPolicyFilePath())
+	if os.IsNotExist(err) {
+		return &Policy{}, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("policy.read_error", err)
+	}
+
+	var policy Policy
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return nil, i18n.Errorf("policy.parse_error", GetPolicyFilePath(), err)
+	}
+	return &policy, nil
+}
+
+// RulesFor retorna as regras em vigor para um provedor. As regras padrão valem sempre, e as do
+// provedor só podem restringir mais: seu deny se soma ao padrão, e seu allow não libera o que o
+// allow padrão deixa de fora. Um arquivo só é enviado se todas as regras retornadas o permitirem.
+func (p *Policy) RulesFor(provider string) []Rules {
+	rules := []Rules{p.Rules}
+	if providerRules, exists := p.Providers[strings.ToLower(provider)]; exists {
+		rules = append(rules, providerRules)
+	}
+	return rules
+}
+
+// Allows indica se as regras permitem enviar o arquivo. Quando não se sabe se o caminho é relativo
+// ao diretório atual ou à raiz do repositório (como os do git diff), ele é negado se qualquer uma
+// das interpretações casar com um glob de deny.
+func (r Rules) Allows(path string) bool {
+	candidates := normalize(path)
+	for _, pattern := range r.Deny {
+		for _, candidate := range candidates {
+			if match(pattern, candidate) {
+				return false
+			}
+		}
+	}
+	if len(r.Allow) == 0 {
+		return true
+	}
+	for _, pattern := range r.Allow {
+		for _, candidate := range candidates {
+			if match(pattern, candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Init carrega a política para o provedor configurado. Uma política inválida interrompe o comando,
+// para que nada seja enviado sem as restrições esperadas.
+func Init() error {
+	provider := "openai"
+	if config, err := commons.LoadConfig(); err == nil && config.AIProvider != "" {
+		provider = config.AIProvider
+	}
+	return Use(provider)
+}
+
+// Use carrega a política para o provedor que de fato receberá o conteúdo, quando o comando
+// não usa o configurado (como o history rerun --provider)
+func Use(provider string) error {
+	policy, err := Load()
+	if err != nil {
+		return err
+	}
+
+	activeProvider = strings.ToLower(provider)
+	active = policy.RulesFor(activeProvider)
+	return nil
+}
+
+// allowsAll indica se todas as regras permitem enviar o arquivo
+func allowsAll(rules []Rules, path string) bool {
+	for _, current := range rules {
+		if !current.Allows(path) {
+			return false
+		}
+	}
+	return true
+}
+
+// Allowed verifica se um arquivo pode ser enviado ao provedor e registra a decisão
+func Allowed(path string) bool {
+	if !allowsAll(active, path) {
+		path = normalize(path)[0]
+		denied = append(denied, path)
+		return false
+	}
+	included = append(included, normalize(path)[0])
+	return true
+}
+
+// Included retorna os arquivos permitidos na execução atual
+func Included() []string {
+	return included
+}
+
+// Denied retorna os arquivos bloqueados pela política na execução atual
+func Denied() []string {
+	return denied
+}
+
+// Provider retorna o provedor para o qual a política foi carregada
+func Provider() string {
+	return activeProvider
+}
+
+// SetShowContext ativa o modo que apenas lista os arquivos que seriam enviados
+func SetShowContext(value bool) {
+	showContext = value
+}
+
+// ShowContext indica se o comando deve apenas listar os arquivos que seriam enviados
+func ShowContext() bool {
+	return showContext
+}
+
+// Stop marca que o comando chegou à fronteira com o provedor no modo --show-context e
+// retorna o erro que interrompe o comando antes de qualquer envio
+func Stop() error {
+	reached = true
+	return errors.New(i18n.T("policy.context_stop"))
+}
+
+// Reached indica se o comando foi interrompido na fronteira com o provedor por --show-context
+func Reached() bool {
+	return reached
+}
+
+// normalize converte o caminho para o formato relativo à raiz do repositório, com barras. Um caminho
+// relativo pode ser do diretório atual (argumentos dos comandos) ou da raiz (saída do git): vale a
+// interpretação em que o arquivo existe e, se não existir em nenhuma, ambas são retornadas.
+func normalize(path string) []string {
+	base := repoRoot()
+	wd := workingDir()
+
+	fromWd := resolve(path)
+	if !filepath.IsAbs(path) {
+		fromWd = filepath.Join(wd, path)
+	}
+	candidates := []string{relative(base, fromWd)}
+	if filepath.IsAbs(path) || wd == base {
+		return candidates
+	}
+
+	fromRoot := filepath.Join(base, path)
+	if _, err := os.Stat(fromWd); err == nil {
+		return candidates
+	}
+	if _, err := os.Stat(fromRoot); err == nil {
+		return []string{relative(base, fromRoot)}
+	}
+	return append(candidates, relative(base, fromRoot))
+}
+
+// relative retorna o caminho relativo à base, com barras; fora dela, o caminho limpo
+func relative(base, path string) string {
+	if rel, err := filepath.Rel(base, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		path = rel
+	}
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// match verifica se o caminho ou algum diretório acima dele casa com o glob.
+// Globs sem barra valem para qualquer nome no caminho (ex: "secrets" ou "*.pem").
+func match(pattern, path string) bool {
+	pattern = strings.TrimSuffix(filepath.ToSlash(strings.TrimPrefix(pattern, "./")), "/")
+	expression := globToRegexp(pattern)
+
+	parts := strings.Split(path, "/")
+	for i := range parts {
+		if expression.MatchString(strings.Join(parts[:i+1], "/")) {
+			return true
+		}
+		if !strings.Contains(pattern, "/") && expression.MatchString(parts[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converte um glob com suporte a ** em expressão regular
+func globToRegexp(pattern string) *regexp.Regexp {
+	var builder strings.Builder
+	builder.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			builder.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			builder.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			builder.WriteString("[^/]*")
+		case pattern[i] == '?':
+			builder.WriteString("[^/]")
+		default:
+			builder.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	builder.WriteString("$")
+	return regexp.MustCompile(builder.String())
+}
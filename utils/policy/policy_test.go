@@ -0,0 +1,118 @@
+package policy
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"**", "a/b/c.go", true},
+		{"*.pem", "key.pem", true},
+		{"*.pem", "infra/certs/key.pem", true},
+		{"*.pem", "key.pem.txt", false},
+		{"secrets", "config/secrets/db.json", true},
+		{"secrets", "config/secretsx/db.json", false},
+		{"contracts/", "contracts/acme.pdf", true},
+		{"./contracts/", "legal/contracts/acme.pdf", true},
+		{"infra/secrets", "infra/secrets/prod/db.env", true},
+		{"infra/secrets/**", "infra/secrets/prod/db.env", true},
+		{"infra/secrets/**", "infra/secretsx/db.env", false},
+		{"infra/secrets/**", "other/infra/secrets/db.env", false},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "src/cmd/main.go", false},
+		{"**/*.key", "server.key", true},
+		{"**/*.key", "a/b/server.key", true},
+		{"docs/**/draft.md", "docs/draft.md", true},
+		{"docs/**/draft.md", "docs/a/b/draft.md", true},
+		{"docs/**/draft.md", "docs/a/b/final.md", false},
+		{"?.txt", "a.txt", true},
+		{"?.txt", "ab.txt", false},
+		{"a+b.txt", "a+b.txt", true},
+		{"a+b.txt", "aab.txt", false},
+	}
+
+	for _, test := range tests {
+		if got := match(test.pattern, test.path); got != test.want {
+			t.Errorf("match(%q, %q) = %v, esperado %v", test.pattern, test.path, got, test.want)
+		}
+	}
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	tests := map[string]string{
+		"**":         `^.*$`,
+		"*.go":       `^[^/]*\.go$`,
+		"a/**/b":     `^a/(?:.*/)?b$`,
+		"file?.json": `^file[^/]\.json$`,
+	}
+	for pattern, want := range tests {
+		if got := globToRegexp(pattern).String(); got != want {
+			t.Errorf("globToRegexp(%q) = %s, esperado %s", pattern, got, want)
+		}
+	}
+}
+
+func TestRulesPrecedence(t *testing.T) {
+	// Caminhos relativos à raiz, que aqui é o diretório atual
+	root = workingDir()
+
+	policy := &Policy{
+		Rules: Rules{Allow: []string{"src/**", "docs/**"}, Deny: []string{"src/secrets/**", "*.pem"}},
+		Providers: map[string]Rules{
+			"anthropic": {Allow: []string{"src/**", "vendor/**"}},
+			"openai":    {Deny: []string{"docs/**"}},
+			"mock":      {},
+		},
+	}
+
+	tests := []struct {
+		provider string
+		path     string
+		want     bool
+	}{
+		// Regras padrão: deny vence allow, e allow restringe o resto
+		{"gemini", "src/main.go", true},
+		{"gemini", "src/secrets/db.go", false},
+		{"gemini", "docs/cert.pem", false},
+		{"gemini", "README.md", false},
+
+		// Um provedor só com allow mantém o deny padrão e não libera o que o allow padrão deixa de fora
+		{"anthropic", "src/main.go", true},
+		{"anthropic", "src/secrets/db.go", false},
+		{"anthropic", "src/key.pem", false},
+		{"anthropic", "vendor/lib.go", false},
+		{"anthropic", "docs/guide.md", false},
+
+		// O deny de um provedor se soma ao padrão
+		{"OpenAI", "docs/guide.md", false},
+		{"openai", "src/main.go", true},
+		{"openai", "src/secrets/db.go", false},
+
+		// Regras vazias de um provedor não mudam nada
+		{"mock", "src/secrets/db.go", false},
+		{"mock", "docs/guide.md", true},
+	}
+
+	for _, test := range tests {
+		if got := allowsAll(policy.RulesFor(test.provider), test.path); got != test.want {
+			t.Errorf("%s: %s permitido = %v, esperado %v", test.provider, test.path, got, test.want)
+		}
+	}
+}
+
+func TestRulesWithoutAllowPermitAll(t *testing.T) {
+	root = workingDir()
+
+	rules := Rules{Deny: []string{"*.env"}}
+	if !rules.Allows("cmd/main.go") {
+		t.Error("sem allow, arquivos fora do deny devem ser permitidos")
+	}
+	if rules.Allows("config/prod.env") {
+		t.Error("config/prod.env deveria ser negado")
+	}
+	if !(Rules{}).Allows("qualquer/arquivo") {
+		t.Error("regras vazias devem permitir tudo")
+	}
+}
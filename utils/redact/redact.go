@@ -0,0 +1,212 @@
+package redact
+
+import (
+	"fmt"
+	"gojira/utils/i18n"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Modos de redação suportados
+const (
+	ModeMask   = "mask"   // Mascara os trechos sensíveis e continua
+	ModeStrict = "strict" // Interrompe o comando se algo sensível for encontrado
+	ModeOff    = "off"    // Não aplica a redação
+)
+
+// Finding representa um tipo de dado sensível encontrado e quantas vezes ele apareceu
+type Finding struct {
+	Kind  string `json:"kind"`
+	Count int    `json:"count"`
+}
+
+// rule associa um tipo de dado sensível à expressão que o detecta.
+// Se group for maior que zero, apenas esse grupo da expressão é mascarado.
+type rule struct {
+	kind    string
+	pattern *regexp.Regexp
+	group   int
+}
+
+// secretRules detecta credenciais conhecidas
+var secretRules = []rule{
+	{kind: "private_key", pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{kind: "aws_access_key", pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{kind: "github_token", pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)},
+	{kind: "slack_token", pattern: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{kind: "google_api_key", pattern: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{kind: "ai_api_key", pattern: regexp.MustCompile(`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}`)},
+	{kind: "jwt", pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`)},
+	{kind: "env_assignment", pattern: regexp.MustCompile(`(?m)^[ \t+-]*(?:export[ \t]+)?[A-Z0-9_]*(?:SECRET|TOKEN|PASSWORD|PASSWD|PWD|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|CREDENTIALS?)[A-Z0-9_]*[ \t]*[=:][ \t]*["']?([^\s"'#]{4,})`), group: 1},
+}
+
+// defaultPIIRules detecta dados pessoais comuns; outros padrões podem ser adicionados pela configuração
+var defaultPIIRules = []rule{
+	{kind: "email", pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`)},
+	{kind: "cpf", pattern: regexp.MustCompile(`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`)},
+	{kind: "credit_card", pattern: regexp.MustCompile(`\b(?:\d{4}[ -]){3}\d{4}\b`)},
+}
+
+// highEntropyPattern encontra candidatos a segredos sem formato conhecido. A barra fica de fora
+// para que caminhos de import e URLs não sejam tomados por segredos.
+var highEntropyPattern = regexp.MustCompile(`[A-Za-z0-9+_=-]{32,}`)
+
+// markerPrefix inicia todo marcador que substitui um trecho sensível
+const markerPrefix = "[REDACTED:"
+
+// Redactor aplica as regras de redação a um texto, guardando os trechos originais para que
+// possam ser restaurados na resposta do provedor
+type Redactor struct {
+	rules     []rule
+	originals map[string]string // marcador -> trecho original
+	markers   map[string]string // tipo e trecho original -> marcador
+}
+
+// New cria um Redactor com as regras padrão e os padrões de dados pessoais adicionais (nome -> expressão regular)
+func New(piiPatterns map[string]string) (*Redactor, error) {
+	rules := append([]rule{}, secretRules...)
+	rules = append(rules, defaultPIIRules...)
+
+	// Ordena os nomes para que a aplicação das regras seja determinística
+	names := make([]string, 0, len(piiPatterns))
+	for name := range piiPatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		pattern, err := regexp.Compile(piiPatterns[name])
+		if err != nil {
+			return nil, i18n.Errorf("redact.invalid_pattern", name, err)
+		}
+		rules = append(rules, rule{kind: name, pattern: pattern})
+	}
+
+	return &Redactor{rules: rules, originals: map[string]string{}, markers: map[string]string{}}, nil
+}
+
+// Redact mascara os trechos sensíveis do texto, retornando o texto mascarado e o que foi encontrado
+func (r *Redactor) Redact(text string) (string, []Finding) {
+	counts := map[string]int{}
+
+	for _, rule := range r.rules {
+		text = r.replace(text, rule, counts)
+	}
+
+	text = highEntropyPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if !isHighEntropy(match) {
+			return match
+		}
+		counts["high_entropy"]++
+		return r.mask("high_entropy", match)
+	})
+
+	findings := make([]Finding, 0, len(counts))
+	for kind, count := range counts {
+		findings = append(findings, Finding{Kind: kind, Count: count})
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Kind < findings[j].Kind
+	})
+
+	return text, findings
+}
+
+// Restore devolve ao texto os trechos originais dos marcadores criados por este Redactor. Cada
+// trecho passa por escape antes de ser inserido (por exemplo, para caber em uma string JSON).
+func (r *Redactor) Restore(text string, escape func(string) string) string {
+	if len(r.originals) == 0 || !Marked(text) {
+		return text
+	}
+
+	pairs := make([]string, 0, len(r.originals)*2)
+	for marker, original := range r.originals {
+		if escape != nil {
+			original = escape(original)
+		}
+		pairs = append(pairs, marker, original)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Marked indica se o texto ainda contém marcadores de redação
+func Marked(text string) bool {
+	return strings.Contains(text, markerPrefix)
+}
+
+// Summary descreve os achados em uma linha (ex: "aws_access_key (1), email (2)")
+func Summary(findings []Finding) string {
+	parts := make([]string, 0, len(findings))
+	for _, finding := range findings {
+		parts = append(parts, fmt.Sprintf("%s (%d)", finding.Kind, finding.Count))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// replace mascara as ocorrências de uma regra, contando quantas foram encontradas
+func (r *Redactor) replace(text string, rule rule, counts map[string]int) string {
+	matches := rule.pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	var builder strings.Builder
+	last := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if rule.group > 0 && len(match) > rule.group*2+1 && match[rule.group*2] >= 0 {
+			start, end = match[rule.group*2], match[rule.group*2+1]
+		}
+		// Trechos já mascarados por uma regra anterior não são contados novamente
+		if strings.HasPrefix(text[start:end], markerPrefix) {
+			continue
+		}
+		builder.WriteString(text[last:start])
+		builder.WriteString(r.mask(rule.kind, text[start:end]))
+		last = end
+		counts[rule.kind]++
+	}
+	builder.WriteString(text[last:])
+
+	return builder.String()
+}
+
+// mask retorna o marcador que substitui um trecho sensível. Cada trecho distinto recebe um marcador
+// numerado, e o mesmo trecho repetido recebe sempre o mesmo marcador.
+func (r *Redactor) mask(kind, original string) string {
+	key := kind + "\x00" + original
+	if marker, exists := r.markers[key]; exists {
+		return marker
+	}
+
+	marker := fmt.Sprintf("%s%s:%d]", markerPrefix, kind, len(r.originals)+1)
+	r.markers[key] = marker
+	r.originals[marker] = original
+	return marker
+}
+
+// isHighEntropy indica se um trecho parece um segredo aleatório: mistura letras e dígitos
+// e tem entropia de Shannon alta o suficiente para descartar palavras e hashes hexadecimais
+func isHighEntropy(value string) bool {
+	hasLetter := strings.ContainsAny(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	hasDigit := strings.ContainsAny(value, "0123456789")
+	if !hasLetter || !hasDigit {
+		return false
+	}
+
+	frequencies := map[rune]float64{}
+	for _, char := range value {
+		frequencies[char]++
+	}
+
+	var entropy float64
+	length := float64(len(value))
+	for _, count := range frequencies {
+		probability := count / length
+		entropy -= probability * math.Log2(probability)
+	}
+
+	return entropy >= 4.3
+}
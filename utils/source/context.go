@@ -0,0 +1,192 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"gojira/utils/dryrun"
+	"gojira/utils/policy"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultContextTokens é o orçamento padrão de tokens do contexto de pacote
+const DefaultContextTokens = 4000
+
+// GoPackageContext reúne, para um arquivo Go, os tipos e as assinaturas de funções do próprio pacote
+// e as declarações exportadas dos pacotes do mesmo módulo que ele importa. O arquivo em si não
+// entra no contexto, e o resultado é limitado a aproximadamente maxTokens tokens.
+func GoPackageContext(file string, maxTokens int) (string, error) {
+	if filepath.Ext(file) != ".go" || maxTokens <= 0 {
+		return "", nil
+	}
+
+	absolute, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+
+	fset := token.NewFileSet()
+	own, err := parser.ParseFile(fset, absolute, nil, parser.ImportsOnly)
+	if err != nil {
+		return "", nil
+	}
+
+	sections := []string{}
+	dir := filepath.Dir(absolute)
+	sections = append(sections, goContextSection(dir, own.Name.Name, own.Name.Name, absolute, false)...)
+
+	// Pacotes importados do mesmo módulo, na ordem dos imports
+	root, module := findGoModule(dir)
+	if module != "" {
+		for _, spec := range own.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil || !strings.HasPrefix(path, module+"/") {
+				continue
+			}
+			importDir := filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, module+"/")))
+			sections = append(sections, goContextSection(importDir, "", path, "", true)...)
+		}
+	}
+
+	// Mantém as declarações dentro do orçamento, na ordem de prioridade; as que não cabem são omitidas
+	var builder strings.Builder
+	tokens := 0
+	omitted := false
+	for _, section := range sections {
+		sectionTokens := dryrun.EstimateTokens(section)
+		if tokens+sectionTokens > maxTokens {
+			omitted = true
+			continue
+		}
+		builder.WriteString(section)
+		tokens += sectionTokens
+	}
+	if omitted {
+		builder.WriteString("// ...\n")
+	}
+	return builder.String(), nil
+}
+
+// goContextSection retorna as declarações de um pacote, começando por um cabeçalho com o rótulo do pacote.
+// Com exportedOnly, apenas tipos e funções exportados são incluídos.
+func goContextSection(dir, packageName, label, skipFile string, exportedOnly bool) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	names := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	fset := token.NewFileSet()
+	types := []string{}
+	functions := []string{}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if path == skipFile || !policy.Allowed(path) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil || (packageName != "" && file.Name.Name != packageName) {
+			continue
+		}
+		if packageName == "" {
+			packageName = file.Name.Name
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GenDecl:
+				if d.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range d.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if exportedOnly && !typeSpec.Name.IsExported() {
+						continue
+					}
+					types = append(types, printNode(fset, &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{typeSpec}}))
+				}
+
+			case *ast.FuncDecl:
+				if d.Recv == nil && (d.Name.Name == "init" || d.Name.Name == "main") {
+					continue
+				}
+				if exportedOnly && (!d.Name.IsExported() || (d.Recv != nil && !ast.IsExported(receiverType(d.Recv.List[0].Type)))) {
+					continue
+				}
+				signature := *d
+				signature.Body = nil
+				signature.Doc = nil
+				functions = append(functions, printNode(fset, &signature))
+			}
+		}
+	}
+
+	if len(types) == 0 && len(functions) == 0 {
+		return nil
+	}
+
+	sections := []string{"// package " + label + "\n"}
+	for _, declaration := range append(types, functions...) {
+		sections = append(sections, declaration+"\n")
+	}
+	return sections
+}
+
+// GoModule retorna o caminho do módulo Go que contém o diretório, ou vazio fora de um módulo
+func GoModule(dir string) string {
+	if absolute, err := filepath.Abs(dir); err == nil {
+		dir = absolute
+	}
+	_, module := findGoModule(dir)
+	return module
+}
+
+// findGoModule procura o go.mod a partir do diretório e retorna a raiz e o caminho do módulo
+func findGoModule(dir string) (string, string) {
+	for {
+		file, err := os.Open(filepath.Join(dir, "go.mod"))
+		if err == nil {
+			defer file.Close()
+			scanner := bufio.NewScanner(file)
+			for scanner.Scan() {
+				line := strings.TrimSpace(scanner.Text())
+				if strings.HasPrefix(line, "module ") {
+					return dir, strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+				}
+			}
+			return dir, ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// printNode formata um nó da AST como código Go
+func printNode(fset *token.FileSet, node interface{}) string {
+	var buffer bytes.Buffer
+	config := &printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	if err := config.Fprint(&buffer, fset, node); err != nil {
+		return ""
+	}
+	return buffer.String()
+}
@@ -0,0 +1,259 @@
+package source
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// goDecl representa uma declaração de nível superior de um arquivo Go
+type goDecl struct {
+	symbol   *Symbol
+	node     ast.Node
+	receiver string
+}
+
+// findGoSymbol localiza a declaração com go/parser
+func findGoSymbol(path, name string) (*Symbol, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("source.read_error", path, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return nil, i18n.Errorf("source.parse_error", path, err)
+	}
+
+	if decl, ok := goDecls(fset, file, path, content)[name]; ok {
+		return decl.symbol, nil
+	}
+	return nil, i18n.Errorf("source.symbol_not_found", name, path)
+}
+
+// goReferences retorna as declarações do mesmo pacote usadas pelo símbolo.
+// Em métodos, chamadas a outros métodos do mesmo receptor também são incluídas.
+func goReferences(symbol *Symbol) ([]*Symbol, error) {
+	index, err := goPackageDecls(filepath.Dir(symbol.File), symbol.File)
+	if err != nil {
+		return nil, err
+	}
+
+	decl, ok := index[symbol.Name]
+	if !ok {
+		return nil, nil
+	}
+
+	// O tipo do receptor, quando houver, é a primeira referência
+	names := []string{}
+	typeName, _, isMethod := strings.Cut(symbol.Name, ".")
+	if isMethod {
+		names = append(names, typeName)
+	}
+
+	ast.Inspect(decl.node, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			// Em pacote.Nome ou receptor.Método, o nome selecionado não é uma declaração do pacote
+			ident, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			if decl.receiver != "" && ident.Name == decl.receiver {
+				names = append(names, typeName+"."+n.Sel.Name)
+			}
+			names = append(names, ident.Name)
+			return false
+		case *ast.Ident:
+			names = append(names, n.Name)
+		}
+		return true
+	})
+
+	references := []*Symbol{}
+	seen := map[string]bool{symbol.Name: true}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if reference, ok := index[name]; ok {
+			references = append(references, reference.symbol)
+		}
+	}
+	return references, nil
+}
+
+// goPackageDecls indexa as declarações de nível superior dos arquivos Go do diretório
+// que pertencem ao mesmo pacote do arquivo informado, ignorando os arquivos de teste
+func goPackageDecls(dir, file string) (map[string]goDecl, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, i18n.Errorf("source.read_error", dir, err)
+	}
+
+	fset := token.NewFileSet()
+	parsed := map[string]*ast.File{}
+	contents := map[string][]byte{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		parsedFile, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		parsed[path] = parsedFile
+		contents[path] = content
+	}
+
+	packageName := ""
+	if own, ok := parsed[file]; ok {
+		packageName = own.Name.Name
+	}
+
+	index := map[string]goDecl{}
+	for path, parsedFile := range parsed {
+		if packageName != "" && parsedFile.Name.Name != packageName {
+			continue
+		}
+		for name, decl := range goDecls(fset, parsedFile, path, contents[path]) {
+			index[name] = decl
+		}
+	}
+	return index, nil
+}
+
+// goDecls indexa as funções, métodos (Tipo.Método), tipos, constantes e variáveis de um arquivo
+func goDecls(fset *token.FileSet, file *ast.File, path string, content []byte) map[string]goDecl {
+	lines := strings.Split(string(content), "\n")
+	decls := map[string]goDecl{}
+
+	add := func(name string, doc *ast.CommentGroup, node ast.Node, receiver string) {
+		decls[name] = goDecl{
+			symbol:   goSymbol(fset, lines, path, name, doc, node),
+			node:     node,
+			receiver: receiver,
+		}
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil || len(d.Recv.List) == 0 {
+				add(d.Name.Name, d.Doc, d, "")
+				continue
+			}
+			field := d.Recv.List[0]
+			receiver := ""
+			if len(field.Names) > 0 {
+				receiver = field.Names[0].Name
+			}
+			add(receiverType(field.Type)+"."+d.Name.Name, d.Doc, d, receiver)
+
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				// Declarações isoladas levam o comentário e a palavra-chave junto
+				var node ast.Node = spec
+				var doc *ast.CommentGroup
+				if len(d.Specs) == 1 {
+					node = d
+					doc = d.Doc
+				}
+
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					if s.Doc != nil {
+						doc = s.Doc
+					}
+					add(s.Name.Name, doc, node, "")
+				case *ast.ValueSpec:
+					if s.Doc != nil {
+						doc = s.Doc
+					}
+					for _, name := range s.Names {
+						if name.Name != "_" {
+							add(name.Name, doc, node, "")
+						}
+					}
+				}
+			}
+		}
+	}
+	return decls
+}
+
+// receiverType retorna o nome do tipo do receptor, sem ponteiro nem parâmetros de tipo
+func receiverType(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return receiverType(e.X)
+	case *ast.IndexExpr:
+		return receiverType(e.X)
+	case *ast.IndexListExpr:
+		return receiverType(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+// GoFunctions retorna as funções e os métodos (Tipo.Método) de um arquivo Go, na ordem do arquivo
+func GoFunctions(path string) ([]*Symbol, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("source.read_error", path, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, content, parser.ParseComments)
+	if err != nil {
+		return nil, i18n.Errorf("source.parse_error", path, err)
+	}
+
+	// Cada símbolo vem da posição da sua própria declaração: várias funções init no mesmo arquivo
+	// têm o mesmo nome, mas trechos diferentes
+	lines := strings.Split(string(content), "\n")
+	functions := []*Symbol{}
+	for _, decl := range file.Decls {
+		function, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		name := function.Name.Name
+		if function.Recv != nil && len(function.Recv.List) > 0 {
+			name = receiverType(function.Recv.List[0].Type) + "." + name
+		}
+		functions = append(functions, goSymbol(fset, lines, path, name, function.Doc, function))
+	}
+	return functions, nil
+}
+
+// goSymbol cria o símbolo de uma declaração, a partir do comentário de documentação
+func goSymbol(fset *token.FileSet, lines []string, path, name string, doc *ast.CommentGroup, node ast.Node) *Symbol {
+	start := node.Pos()
+	if doc != nil {
+		start = doc.Pos()
+	}
+	startLine := fset.Position(start).Line
+	endLine := fset.Position(node.End()).Line
+	return &Symbol{
+		Name:      name,
+		File:      path,
+		StartLine: startLine,
+		EndLine:   endLine,
+		Code:      strings.Join(lines[startLine-1:endLine], "\n"),
+	}
+}
@@ -0,0 +1,605 @@
+package source
+
+import (
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"gojira/utils/i18n"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Merge representa o resultado da mesclagem de testes gerados em um arquivo de testes existente
+type Merge struct {
+	Code       string   `json:"-"`
+	Added      []string `json:"added"`
+	Replaced   []string `json:"replaced"`
+	Collisions []string `json:"collisions"`
+}
+
+// edit representa a substituição de um trecho do arquivo existente
+type edit struct {
+	start, end int
+	text       string
+}
+
+// unit representa uma declaração de nível superior, ou uma especificação de um bloco var, const
+// ou type, que pode ser adicionada ou substituída de forma independente das demais
+type unit struct {
+	names      []string
+	start, end int
+	grouped    bool   // especificação dentro de um bloco entre parênteses
+	spec       string // texto para um bloco entre parênteses, vazio para funções
+	decl       string // texto da declaração completa
+}
+
+// MergeGoTests acrescenta ao arquivo de testes existente as declarações do código gerado.
+// Declarações com nomes já existentes só substituem as atuais quando estão em replace; as demais
+// são mantidas e informadas como colisões. Os imports são unidos e os que ficaram sem uso, removidos.
+// module é o caminho do módulo do arquivo, cujos pacotes não são agrupados com a biblioteca padrão.
+func MergeGoTests(existing, generated, module string, replace []string) (*Merge, error) {
+	fset := token.NewFileSet()
+	oldFile, err := parser.ParseFile(fset, "existing.go", existing, parser.ParseComments)
+	if err != nil {
+		return nil, i18n.Errorf("source.merge_existing_error", err)
+	}
+	newFile, err := parser.ParseFile(fset, "generated.go", generated, parser.ParseComments)
+	if err != nil {
+		return nil, i18n.Errorf("source.merge_generated_error", err)
+	}
+	if oldFile.Name.Name != newFile.Name.Name {
+		return nil, i18n.Errorf("source.merge_package_mismatch", newFile.Name.Name, oldFile.Name.Name)
+	}
+
+	replaceable := map[string]bool{}
+	for _, name := range replace {
+		replaceable[name] = true
+	}
+
+	// Trechos das declarações existentes, incluindo os comentários de documentação
+	spans := map[string]unit{}
+	for _, decl := range oldFile.Decls {
+		if isImport(decl) {
+			continue
+		}
+		for _, existingUnit := range declUnits(fset, existing, decl) {
+			for _, name := range existingUnit.names {
+				spans[name] = existingUnit
+			}
+		}
+	}
+
+	merge := &Merge{Added: []string{}, Replaced: []string{}, Collisions: []string{}}
+	edits := []edit{}
+	appended := []string{}
+	replaced := map[int]bool{}
+	for _, decl := range newFile.Decls {
+		if isImport(decl) {
+			continue
+		}
+
+		// Um bloco sem nenhuma colisão é acrescentado inteiro; os demais, especificação por especificação
+		units := declUnits(fset, generated, decl)
+		if !anyIn(units, spans) {
+			start, end := declSpan(fset, decl)
+			appended = append(appended, generated[start:end])
+			for _, newUnit := range units {
+				merge.Added = append(merge.Added, newUnit.names...)
+			}
+			continue
+		}
+
+		for _, newUnit := range units {
+			target, exists := firstSpan(spans, newUnit.names)
+			switch {
+			case !exists:
+				appended = append(appended, newUnit.decl)
+				merge.Added = append(merge.Added, newUnit.names...)
+			case allIn(newUnit.names, replaceable) && !replaced[target.start] && (!target.grouped || newUnit.spec != ""):
+				replaced[target.start] = true
+				text := newUnit.decl
+				if target.grouped {
+					text = newUnit.spec
+				}
+				edits = append(edits, edit{start: target.start, end: target.end, text: text})
+				merge.Replaced = append(merge.Replaced, newUnit.names...)
+			default:
+				merge.Collisions = append(merge.Collisions, newUnit.names...)
+			}
+		}
+	}
+
+	// Os imports existentes são mantidos como estão, com seus comentários; os novos entram no grupo
+	// correspondente do bloco existente. O nome de um pacote só é considerado conhecido quando vem
+	// de um alias, da biblioteca padrão ou é usado no arquivo de origem, já que o caminho nem sempre
+	// indica o nome (github.com/nats-io/nats.go).
+	imports := newImportSet(oldFile, newFile, module)
+	build := func(imports *importSet) string {
+		all := append(imports.edits(fset, existing, oldFile), edits...)
+		sort.SliceStable(all, func(i, j int) bool { return all[i].start > all[j].start })
+
+		code := existing
+		for _, change := range all {
+			code = code[:change.start] + change.text + code[change.end:]
+		}
+		code = strings.TrimRight(code, "\n") + "\n"
+		for _, text := range appended {
+			code += "\n" + text + "\n"
+		}
+		return code
+	}
+
+	// Remove os imports de nome conhecido que nenhum código usa mais, como faria o goimports; os
+	// demais são mantidos, para não quebrar um arquivo que compilava
+	used, err := usedPackages(build(imports))
+	if err != nil {
+		return nil, i18n.Errorf("source.merge_generated_error", err)
+	}
+	imports.prune(used)
+
+	formatted, err := format.Source([]byte(build(imports)))
+	if err != nil {
+		return nil, i18n.Errorf("source.merge_generated_error", err)
+	}
+	merge.Code = string(formatted)
+	return merge, nil
+}
+
+// GoPackageName retorna o nome do pacote de um arquivo Go, ou vazio se o arquivo não puder ser lido
+func GoPackageName(code string) string {
+	file, err := parser.ParseFile(token.NewFileSet(), "", code, parser.PackageClauseOnly)
+	if err != nil {
+		return ""
+	}
+	return file.Name.Name
+}
+
+// declSpan retorna o trecho de uma declaração, a partir do comentário de documentação
+func declSpan(fset *token.FileSet, decl ast.Decl) (int, int) {
+	start := decl.Pos()
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			start = d.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			start = d.Doc.Pos()
+		}
+	}
+	return fset.Position(start).Offset, fset.Position(decl.End()).Offset
+}
+
+// declUnits divide uma declaração nas partes que podem ser mescladas separadamente: funções e
+// declarações sem parênteses são uma parte só; blocos var, const e type, uma por especificação.
+// Blocos const com valores implícitos (iota) não são divididos, pois dependem da ordem.
+func declUnits(fset *token.FileSet, code string, decl ast.Decl) []unit {
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	gen, ok := decl.(*ast.GenDecl)
+	if !ok || !gen.Lparen.IsValid() || !splittable(gen) {
+		start, end := declSpan(fset, decl)
+		whole := unit{names: declNames(decl), start: start, end: end, decl: code[start:end]}
+		if ok && !gen.Lparen.IsValid() && len(gen.Specs) == 1 {
+			body := code[offset(gen.Specs[0].Pos()):end]
+			whole.spec = joinDoc(gen.Doc, body)
+		}
+		return []unit{whole}
+	}
+
+	units := []unit{}
+	for _, spec := range gen.Specs {
+		doc, comment := specComments(spec)
+		start, end := offset(spec.Pos()), offset(spec.End())
+		if comment != nil {
+			end = offset(comment.End())
+		}
+		body := code[start:end]
+		if doc != nil {
+			start = offset(doc.Pos())
+		}
+		units = append(units, unit{
+			names:   specNames(spec),
+			start:   start,
+			end:     end,
+			grouped: true,
+			spec:    code[start:end],
+			decl:    joinDoc(doc, gen.Tok.String()+" "+body),
+		})
+	}
+	return units
+}
+
+// splittable indica se as especificações de um bloco podem ser separadas sem mudar seu significado
+func splittable(gen *ast.GenDecl) bool {
+	if gen.Tok != token.CONST {
+		return true
+	}
+	for _, spec := range gen.Specs {
+		if value, ok := spec.(*ast.ValueSpec); ok && len(value.Values) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// specComments retorna o comentário de documentação e o comentário de linha de uma especificação
+func specComments(spec ast.Spec) (*ast.CommentGroup, *ast.CommentGroup) {
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		return s.Doc, s.Comment
+	case *ast.ValueSpec:
+		return s.Doc, s.Comment
+	}
+	return nil, nil
+}
+
+// joinDoc escreve o comentário de documentação antes do código
+func joinDoc(doc *ast.CommentGroup, code string) string {
+	if doc == nil {
+		return code
+	}
+	lines := []string{}
+	for _, comment := range doc.List {
+		lines = append(lines, comment.Text)
+	}
+	return strings.Join(lines, "\n") + "\n" + code
+}
+
+// declNames retorna os nomes definidos por uma declaração, com métodos no formato Tipo.Método
+func declNames(decl ast.Decl) []string {
+	names := []string{}
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		name := d.Name.Name
+		if d.Recv != nil && len(d.Recv.List) > 0 {
+			name = receiverType(d.Recv.List[0].Type) + "." + name
+		}
+		names = append(names, name)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			names = append(names, specNames(spec)...)
+		}
+	}
+	return names
+}
+
+// specNames retorna os nomes definidos por uma especificação de tipo ou valor
+func specNames(spec ast.Spec) []string {
+	names := []string{}
+	switch s := spec.(type) {
+	case *ast.TypeSpec:
+		names = append(names, s.Name.Name)
+	case *ast.ValueSpec:
+		for _, name := range s.Names {
+			if name.Name != "_" {
+				names = append(names, name.Name)
+			}
+		}
+	}
+	return names
+}
+
+// isImport indica se a declaração é um bloco de imports
+func isImport(decl ast.Decl) bool {
+	gen, ok := decl.(*ast.GenDecl)
+	return ok && gen.Tok == token.IMPORT
+}
+
+// firstSpan retorna a primeira parte existente com algum dos nomes
+func firstSpan(spans map[string]unit, names []string) (unit, bool) {
+	for _, name := range names {
+		if span, exists := spans[name]; exists {
+			return span, true
+		}
+	}
+	return unit{}, false
+}
+
+// anyIn indica se alguma das partes tem um nome já existente
+func anyIn(units []unit, spans map[string]unit) bool {
+	for _, part := range units {
+		if _, exists := firstSpan(spans, part.names); exists {
+			return true
+		}
+	}
+	return false
+}
+
+// allIn indica se todos os nomes estão no conjunto
+func allIn(names []string, set map[string]bool) bool {
+	for _, name := range names {
+		if !set[name] {
+			return false
+		}
+	}
+	return len(names) > 0
+}
+
+// importSpec representa um import de um dos arquivos
+type importSpec struct {
+	path    string
+	alias   string
+	known   bool
+	removed bool
+	spec    *ast.ImportSpec // nil para os imports que vêm do código gerado
+}
+
+// name retorna o nome pelo qual o pacote é usado no código
+func (i *importSpec) name() string {
+	if i.alias != "" {
+		return i.alias
+	}
+	return importName(i.path)
+}
+
+// text escreve o import com ou sem alias
+func (i *importSpec) text() string {
+	if i.alias != "" {
+		return i.alias + " " + strconv.Quote(i.path)
+	}
+	return strconv.Quote(i.path)
+}
+
+// importSet reúne os imports do arquivo existente e os que o código gerado acrescenta
+type importSet struct {
+	module   string
+	existing []*importSpec
+	added    []*importSpec
+}
+
+// newImportSet une os imports dos dois arquivos; um import do código gerado só é novo se nenhum
+// import existente tiver o mesmo caminho e o mesmo nome
+func newImportSet(oldFile, newFile *ast.File, module string) *importSet {
+	set := &importSet{module: module}
+	seen := map[string]bool{}
+	for _, file := range []*ast.File{oldFile, newFile} {
+		usedInFile := usedIdents(file)
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			current := &importSpec{path: path}
+			if spec.Name != nil {
+				current.alias = spec.Name.Name
+			}
+			current.known = current.alias != "" || isStandard(path, module) || usedInFile[importName(path)]
+
+			key := current.alias + " " + path
+			if file == oldFile {
+				current.spec = spec
+				set.existing = append(set.existing, current)
+			} else if !seen[key] {
+				set.added = append(set.added, current)
+			}
+			seen[key] = true
+		}
+	}
+	return set
+}
+
+// prune marca como removidos os imports de nome conhecido que o código não usa
+func (s *importSet) prune(used map[string]bool) {
+	for _, current := range append(append([]*importSpec{}, s.existing...), s.added...) {
+		name := current.name()
+		if current.known && name != "_" && name != "." && !used[name] {
+			current.removed = true
+		}
+	}
+}
+
+// edits retorna as alterações no arquivo existente: as linhas dos imports removidos são apagadas e
+// os novos imports entram após o último import do mesmo grupo (biblioteca padrão ou não) do primeiro
+// bloco entre parênteses. Sem esse bloco, os novos imports ganham um bloco próprio.
+func (s *importSet) edits(fset *token.FileSet, code string, file *ast.File) []edit {
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	edits := []edit{}
+	var block *ast.GenDecl
+	anchors := map[bool]int{}
+	insertAt := offset(file.Name.End())
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+
+		kept, removed := []*importSpec{}, []*importSpec{}
+		for _, current := range s.existing {
+			switch {
+			case !containsSpec(gen, current.spec):
+			case current.removed:
+				removed = append(removed, current)
+			default:
+				kept = append(kept, current)
+			}
+		}
+
+		// Uma declaração sem nenhum import restante é apagada inteira, com seu comentário
+		start, end := declSpan(fset, gen)
+		if len(kept) == 0 {
+			edits = append(edits, edit{start: start, end: end})
+			continue
+		}
+		for _, current := range removed {
+			specStart, specEnd := offset(current.spec.Pos()), offset(current.spec.End())
+			if current.spec.Doc != nil {
+				specStart = offset(current.spec.Doc.Pos())
+			}
+			if current.spec.Comment != nil {
+				specEnd = offset(current.spec.Comment.End())
+			}
+			specStart, specEnd = lineSpan(code, specStart, specEnd)
+			edits = append(edits, edit{start: specStart, end: specEnd})
+		}
+		insertAt = end
+		if block == nil && gen.Lparen.IsValid() {
+			block = gen
+			for _, current := range kept {
+				lineEnd := offset(current.spec.End())
+				if current.spec.Comment != nil {
+					lineEnd = offset(current.spec.Comment.End())
+				}
+				anchors[isStandard(current.path, s.module)] = lineEnd
+			}
+		}
+	}
+
+	// Agrupa os novos imports pelo ponto de inserção
+	standard, others := []string{}, []string{}
+	for _, current := range s.added {
+		if current.removed {
+			continue
+		}
+		if isStandard(current.path, s.module) {
+			standard = append(standard, current.text())
+		} else {
+			others = append(others, current.text())
+		}
+	}
+	sort.Strings(standard)
+	sort.Strings(others)
+
+	if block == nil {
+		imports := map[string]string{}
+		for _, current := range s.added {
+			if !current.removed {
+				imports[current.path] = current.alias
+			}
+		}
+		return append(edits, edit{start: insertAt, end: insertAt, text: importBlock(imports, s.module)})
+	}
+
+	for _, group := range []struct {
+		standard bool
+		specs    []string
+	}{{true, standard}, {false, others}} {
+		if len(group.specs) == 0 {
+			continue
+		}
+		text := "\n\t" + strings.Join(group.specs, "\n\t")
+		if anchor, exists := anchors[group.standard]; exists {
+			edits = append(edits, edit{start: anchor, end: anchor, text: text})
+		} else if group.standard {
+			position := offset(block.Lparen) + 1
+			edits = append(edits, edit{start: position, end: position, text: text + "\n"})
+		} else {
+			position := offset(block.Rparen)
+			edits = append(edits, edit{start: position, end: position, text: text + "\n"})
+		}
+	}
+	return edits
+}
+
+// containsSpec indica se o import pertence à declaração
+func containsSpec(gen *ast.GenDecl, spec *ast.ImportSpec) bool {
+	for _, current := range gen.Specs {
+		if current == spec {
+			return true
+		}
+	}
+	return false
+}
+
+// lineSpan estende o trecho às linhas inteiras, incluindo a quebra de linha final
+func lineSpan(code string, start, end int) (int, int) {
+	start = strings.LastIndex(code[:start], "\n") + 1
+	if next := strings.Index(code[end:], "\n"); next >= 0 {
+		return start, end + next + 1
+	}
+	return start, len(code)
+}
+
+// importBlock escreve os imports como o goimports: em ordem alfabética, com a biblioteca padrão
+// separada dos demais pacotes
+func importBlock(imports map[string]string, module string) string {
+	standard := []string{}
+	others := []string{}
+	for path := range imports {
+		spec := strconv.Quote(path)
+		if alias := imports[path]; alias != "" {
+			spec = alias + " " + spec
+		}
+		if !isStandard(path, module) {
+			others = append(others, spec)
+		} else {
+			standard = append(standard, spec)
+		}
+	}
+	sort.Slice(standard, func(i, j int) bool { return specPath(standard[i]) < specPath(standard[j]) })
+	sort.Slice(others, func(i, j int) bool { return specPath(others[i]) < specPath(others[j]) })
+
+	switch {
+	case len(imports) == 0:
+		return ""
+	case len(imports) == 1:
+		return "\n\nimport " + append(standard, others...)[0]
+	}
+
+	groups := []string{}
+	for _, group := range [][]string{standard, others} {
+		if len(group) > 0 {
+			groups = append(groups, "\t"+strings.Join(group, "\n\t"))
+		}
+	}
+	return "\n\nimport (\n" + strings.Join(groups, "\n\n") + "\n)"
+}
+
+// specPath retorna o caminho de um import escrito com ou sem alias
+func specPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
+}
+
+// usedPackages retorna os identificadores usados como pacote (pacote.Nome) no código
+func usedPackages(code string) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "merged.go", code, 0)
+	if err != nil {
+		return nil, err
+	}
+	return usedIdents(file), nil
+}
+
+// usedIdents retorna os identificadores usados como pacote (pacote.Nome) no arquivo
+func usedIdents(file *ast.File) map[string]bool {
+	used := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return used
+}
+
+// isStandard indica se o caminho é de um pacote da biblioteca padrão, cujo nome é o último elemento.
+// Os pacotes do próprio módulo não têm ponto no primeiro elemento (gojira/utils), mas não são da
+// biblioteca padrão.
+func isStandard(path, module string) bool {
+	if module != "" && (path == module || strings.HasPrefix(path, module+"/")) {
+		return false
+	}
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// versionSuffix casa com sufixos de versão em caminhos de import (v2, yaml.v3)
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$|\.v[0-9]+$`)
+
+// importName deduz o nome do pacote a partir do caminho do import
+func importName(path string) string {
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if versionSuffix.MatchString(name) && len(parts) > 1 && strings.HasPrefix(name, "v") {
+		name = parts[len(parts)-2]
+	}
+	name = versionSuffix.ReplaceAllString(name, "")
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "")
+}
@@ -0,0 +1,143 @@
+package source
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMergeGoTests(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		generated  string
+		replace    []string
+		want       string
+		added      []string
+		replaced   []string
+		collisions []string
+	}{
+		{
+			name:      "adiciona testes novos",
+			existing:  "package app\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n",
+			generated: "package app\n\nimport \"testing\"\n\n// TestB testa B\nfunc TestB(t *testing.T) {}\n",
+			want:      "package app\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) {}\n\n// TestB testa B\nfunc TestB(t *testing.T) {}\n",
+			added:     []string{"TestB"},
+		},
+		{
+			name:       "mantém os testes existentes em caso de colisão",
+			existing:   "package app\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) { t.Log(1) }\n",
+			generated:  "package app\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) { t.Log(2) }\n",
+			want:       "package app\n\nimport \"testing\"\n\nfunc TestA(t *testing.T) { t.Log(1) }\n",
+			collisions: []string{"TestA"},
+		},
+		{
+			name:      "substitui os testes pedidos",
+			existing:  "package app\n\nimport \"testing\"\n\n// TestA antigo\nfunc TestA(t *testing.T) { t.Log(1) }\n\nfunc TestZ(t *testing.T) {}\n",
+			generated: "package app\n\nimport \"testing\"\n\n// TestA novo\nfunc TestA(t *testing.T) { t.Log(2) }\n",
+			replace:   []string{"TestA"},
+			want:      "package app\n\nimport \"testing\"\n\n// TestA novo\nfunc TestA(t *testing.T) { t.Log(2) }\n\nfunc TestZ(t *testing.T) {}\n",
+			replaced:  []string{"TestA"},
+		},
+		{
+			name:       "divide blocos com colisão parcial",
+			existing:   "package app\n\nvar a = 1\n",
+			generated:  "package app\n\nvar (\n\ta = 2\n\t// b é novo\n\tb = 3\n)\n",
+			want:       "package app\n\nvar a = 1\n\n// b é novo\nvar b = 3\n",
+			added:      []string{"b"},
+			collisions: []string{"a"},
+		},
+		{
+			name:      "substitui uma especificação dentro de um bloco",
+			existing:  "package app\n\nvar (\n\ta = 1\n\tb = 2 // mantido\n)\n",
+			generated: "package app\n\nvar a = 9\n",
+			replace:   []string{"a"},
+			want:      "package app\n\nvar (\n\ta = 9\n\tb = 2 // mantido\n)\n",
+			replaced:  []string{"a"},
+		},
+		{
+			name:       "não divide blocos const com iota",
+			existing:   "package app\n\nconst x = 0\n",
+			generated:  "package app\n\nconst (\n\tx = iota\n\ty\n)\n",
+			want:       "package app\n\nconst x = 0\n",
+			collisions: []string{"x", "y"},
+		},
+		{
+			name: "une os imports e remove os que ficaram sem uso",
+			existing: "package app\n\nimport (\n\t// strings só é usado em TestA\n\t\"strings\"\n\t\"testing\" // testes\n\n" +
+				"\t\"github.com/nats-io/nats.go\"\n)\n\nvar _ = nats.Connect\n\nfunc TestA(t *testing.T) { _ = strings.ToUpper(\"a\") }\n",
+			generated: "package app\n\nimport (\n\t\"fmt\"\n\t\"testing\"\n\n\t\"gojira/utils/i18n\"\n)\n\n" +
+				"func TestA(t *testing.T) { t.Log(fmt.Sprint(i18n.T(\"a\"))) }\n",
+			replace: []string{"TestA"},
+			want: "package app\n\nimport (\n\t\"fmt\"\n\t\"testing\" // testes\n\n\t\"github.com/nats-io/nats.go\"\n\t\"gojira/utils/i18n\"\n)\n\n" +
+				"var _ = nats.Connect\n\nfunc TestA(t *testing.T) { t.Log(fmt.Sprint(i18n.T(\"a\"))) }\n",
+			replaced: []string{"TestA"},
+		},
+		{
+			name:      "mantém imports cujo nome não é conhecido",
+			existing:  "package app\n\nimport (\n\t\"testing\"\n\n\t\"example.com/go-thing/v2\"\n)\n\nfunc TestA(t *testing.T) {}\n",
+			generated: "package app\n\nimport \"testing\"\n\nfunc TestB(t *testing.T) {}\n",
+			want:      "package app\n\nimport (\n\t\"testing\"\n\n\t\"example.com/go-thing/v2\"\n)\n\nfunc TestA(t *testing.T) {}\n\nfunc TestB(t *testing.T) {}\n",
+			added:     []string{"TestB"},
+		},
+		{
+			name:      "cria o bloco de imports quando o arquivo não tem um",
+			existing:  "package app\n\nfunc helper() int { return 1 }\n",
+			generated: "package app\n\nimport (\n\t\"testing\"\n\n\t\"gojira/utils/i18n\"\n)\n\nfunc TestB(t *testing.T) { t.Log(i18n.T(\"b\"), helper()) }\n",
+			want:      "package app\n\nimport (\n\t\"testing\"\n\n\t\"gojira/utils/i18n\"\n)\n\nfunc helper() int { return 1 }\n\nfunc TestB(t *testing.T) { t.Log(i18n.T(\"b\"), helper()) }\n",
+			added:     []string{"TestB"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merge, err := MergeGoTests(test.existing, test.generated, "gojira", test.replace)
+			if err != nil {
+				t.Fatalf("MergeGoTests: %v", err)
+			}
+			if merge.Code != test.want {
+				t.Errorf("código mesclado:\n%s\nesperado:\n%s", merge.Code, test.want)
+			}
+			for _, check := range []struct {
+				label     string
+				got, want []string
+			}{
+				{"adicionados", merge.Added, test.added},
+				{"substituídos", merge.Replaced, test.replaced},
+				{"colisões", merge.Collisions, test.collisions},
+			} {
+				if check.want == nil {
+					check.want = []string{}
+				}
+				if !reflect.DeepEqual(check.got, check.want) {
+					t.Errorf("%s = %v, esperado %v", check.label, check.got, check.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeGoTestsRejectsPackageMismatch(t *testing.T) {
+	_, err := MergeGoTests("package app\n", "package app_test\n\nfunc TestA() {}\n", "", nil)
+	if err == nil || !strings.Contains(err.Error(), "app_test") {
+		t.Errorf("erro = %v, esperado pacote diferente", err)
+	}
+}
+
+func TestIsStandard(t *testing.T) {
+	tests := map[string]bool{
+		"fmt":                        true,
+		"net/http":                   true,
+		"gojira":                     false,
+		"gojira/utils/i18n":          false,
+		"gojiraext/x":                true,
+		"github.com/spf13/cobra":     false,
+		"gopkg.in/yaml.v3":           false,
+		"github.com/nats-io/nats.go": false,
+	}
+	for path, want := range tests {
+		if got := isStandard(path, "gojira"); got != want {
+			t.Errorf("isStandard(%q) = %v, esperado %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package source
+
+import (
+	"gojira/utils/i18n"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxReferences limita quantas definições referenciadas acompanham um símbolo fora de Go
+const maxReferences = 10
+
+// Symbol representa a definição de uma função, método, tipo ou classe em um arquivo de código
+type Symbol struct {
+	Name      string `json:"name"`
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Code      string `json:"-"`
+}
+
+// FindSymbol localiza a definição de name no arquivo. O nome pode ser uma função ou tipo (Nome)
+// ou um método (Tipo.Método). Arquivos Go são analisados com go/parser; os demais, por expressões regulares.
+func FindSymbol(path, name string) (*Symbol, error) {
+	if filepath.Ext(path) == ".go" {
+		return findGoSymbol(path, name)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("source.read_error", path, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	symbol := findRegexSymbol(lines, 0, len(lines), name, filepath.Ext(path))
+	if symbol == nil {
+		return nil, i18n.Errorf("source.symbol_not_found", name, path)
+	}
+	symbol.File = path
+	return symbol, nil
+}
+
+// References retorna as definições de tipos e funções usados pelo símbolo. Em Go, são buscadas
+// em todo o pacote; nas demais linguagens, apenas no próprio arquivo.
+func References(symbol *Symbol) ([]*Symbol, error) {
+	if filepath.Ext(symbol.File) == ".go" {
+		return goReferences(symbol)
+	}
+
+	content, err := os.ReadFile(symbol.File)
+	if err != nil {
+		return nil, i18n.Errorf("source.read_error", symbol.File, err)
+	}
+	lines := strings.Split(string(content), "\n")
+	ext := filepath.Ext(symbol.File)
+
+	references := []*Symbol{}
+	seen := map[string]bool{}
+	for _, name := range identifierPattern.FindAllString(symbol.Code, -1) {
+		if seen[name] || strings.HasSuffix(symbol.Name, name) {
+			continue
+		}
+		seen[name] = true
+
+		reference := findRegexSymbol(lines, 0, len(lines), name, ext)
+		if reference == nil || (reference.StartLine <= symbol.EndLine && reference.EndLine >= symbol.StartLine) {
+			continue
+		}
+		reference.File = symbol.File
+		references = append(references, reference)
+		if len(references) >= maxReferences {
+			break
+		}
+	}
+	return references, nil
+}
+
+// identifierPattern casa com identificadores candidatos a referência
+var identifierPattern = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]{2,}\b`)
+
+// definitionPatterns reconhecem a linha que define um símbolo nas linguagens mais comuns.
+// %s é substituído pelo nome do símbolo.
+var definitionPatterns = []string{
+	// def, function, class, fn, func, etc. (Python, JavaScript, Ruby, Rust, Kotlin, Swift, PHP...)
+	`^\s*(?:(?:export|default|async|pub(?:\([^)]*\))?|public|private|protected|internal|static|abstract|final|open|data|sealed)\s+)*` +
+		`(?:def|function\*?|class|interface|struct|enum|trait|type|fn|func|fun|sub|module|impl|object|record)\s+(?:self\.)?%s\b`,
+	// const nome = ... (funções anônimas em JavaScript e TypeScript)
+	`^\s*(?:export\s+)?(?:const|let|var)\s+%s\s*[:=]`,
+	// Métodos com tipo de retorno (Java, C#, C++)
+	`^\s*(?:(?:public|private|protected|internal|static|final|abstract|override|virtual|async|synchronized|inline)\s+)*` +
+		`[\w<>\[\],.?*&:]+\s+%s\s*\(`,
+	// Métodos de classe sem palavra-chave (JavaScript, TypeScript)
+	`^\s*(?:(?:public|private|protected|static|async|get|set)\s+)*%s\s*\([^;]*$`,
+}
+
+// statementPattern casa com instruções que chamam o símbolo em vez de defini-lo
+var statementPattern = regexp.MustCompile(`^\s*(?:return|new|await|throw|else|yield|case|if|while|for|echo|print|puts)\b`)
+
+// findRegexSymbol procura a definição entre as linhas from e to. Em Tipo.Método, localiza
+// primeiro o tipo e depois o método dentro dele.
+func findRegexSymbol(lines []string, from, to int, name, ext string) *Symbol {
+	if typeName, method, found := strings.Cut(name, "."); found {
+		owner := findRegexSymbol(lines, from, to, typeName, ext)
+		if owner == nil {
+			return nil
+		}
+		symbol := findRegexSymbol(lines, owner.StartLine, owner.EndLine, method, ext)
+		if symbol != nil {
+			symbol.Name = name
+		}
+		return symbol
+	}
+
+	for _, pattern := range definitionPatterns {
+		expression := regexp.MustCompile(strings.ReplaceAll(pattern, "%s", regexp.QuoteMeta(name)))
+		for i := from; i < to && i < len(lines); i++ {
+			if !expression.MatchString(lines[i]) || isComment(lines[i]) || statementPattern.MatchString(lines[i]) {
+				continue
+			}
+
+			start := i
+			for start > from && isComment(lines[start-1]) {
+				start--
+			}
+			end := blockEnd(lines, i, ext)
+			return &Symbol{
+				Name:      name,
+				StartLine: start + 1,
+				EndLine:   end + 1,
+				Code:      strings.Join(lines[start:end+1], "\n"),
+			}
+		}
+	}
+	return nil
+}
+
+// blockEnd encontra a última linha do bloco iniciado em start: pelo balanceamento de chaves
+// quando o bloco abre uma, ou pela indentação (Python, Ruby e afins)
+func blockEnd(lines []string, start int, ext string) int {
+	if ext != ".py" {
+		depth := 0
+		opened := false
+		for i := start; i < len(lines); i++ {
+			line := stripStrings(lines[i])
+			if !opened && i > start+3 {
+				break
+			}
+			if !opened && strings.HasSuffix(strings.TrimSpace(line), ";") {
+				return i
+			}
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+			if strings.Contains(line, "{") {
+				opened = true
+			}
+			if opened && depth <= 0 {
+				return i
+			}
+		}
+	}
+
+	indent := indentation(lines[start])
+	end := start
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if trimmed == "" {
+			continue
+		}
+		if indentation(lines[i]) <= indent {
+			// Linguagens que fecham blocos com "end" incluem a linha de fechamento
+			if trimmed == "end" || strings.HasPrefix(trimmed, "end ") {
+				return i
+			}
+			break
+		}
+		end = i
+	}
+	return end
+}
+
+// stringPattern casa com literais de texto simples, para que chaves dentro deles não contem
+var stringPattern = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`")
+
+// stripStrings remove literais de texto e comentários de linha antes da contagem de chaves
+func stripStrings(line string) string {
+	line = stringPattern.ReplaceAllString(line, `""`)
+	if index := strings.Index(line, "//"); index >= 0 {
+		line = line[:index]
+	}
+	return line
+}
+
+// isComment indica se a linha é um comentário ou um decorador que acompanha a definição seguinte
+func isComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"//", "#", "/*", "*", "@", "--"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// indentation retorna a largura da indentação da linha, contando tabulações como quatro espaços
+func indentation(line string) int {
+	width := 0
+	for _, char := range line {
+		switch char {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
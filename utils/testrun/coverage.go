@@ -0,0 +1,150 @@
+package testrun
+
+import (
+	"bufio"
+	"gojira/utils/i18n"
+	"gojira/utils/source"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Block representa um trecho do perfil de cobertura do Go
+type Block struct {
+	File       string
+	StartLine  int
+	EndLine    int
+	Statements int
+	Covered    bool
+}
+
+// Coverage representa a cobertura de um pacote Go
+type Coverage struct {
+	Percent float64
+	Blocks  []Block
+}
+
+// Gap representa uma função sem cobertura total, com as linhas que nenhum teste executa
+type Gap struct {
+	Function  string   `json:"function"`
+	Uncovered bool     `json:"uncovered"`
+	Lines     []string `json:"lines"`
+}
+
+// GoCoverage executa os testes do pacote no diretório com -coverprofile e lê a cobertura.
+// Testes que falham não impedem a leitura; erros de compilação, sim.
+func GoCoverage(dir string) (*Coverage, error) {
+	profile, err := os.CreateTemp("", "gojira-cover-*.out")
+	if err != nil {
+		return nil, i18n.Errorf("testrun.coverage_error", err)
+	}
+	_ = profile.Close()
+	defer os.Remove(profile.Name())
+
+	output, _, err := run(dir, "go", "test", "-count=1", "-coverprofile="+profile.Name(), ".")
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(profile.Name())
+	if err != nil {
+		return nil, i18n.Errorf("testrun.coverage_error", strings.TrimSpace(output))
+	}
+	defer file.Close()
+
+	coverage := &Coverage{}
+	total, covered := 0, 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		block, ok := parseBlock(scanner.Text())
+		if !ok {
+			continue
+		}
+		coverage.Blocks = append(coverage.Blocks, block)
+		total += block.Statements
+		if block.Covered {
+			covered += block.Statements
+		}
+	}
+	if len(coverage.Blocks) == 0 {
+		return nil, i18n.Errorf("testrun.coverage_error", strings.TrimSpace(output))
+	}
+	if total > 0 {
+		coverage.Percent = float64(covered) * 100 / float64(total)
+	}
+	return coverage, nil
+}
+
+// parseBlock interpreta uma linha do perfil: arquivo:linha.coluna,linha.coluna instruções contagem
+func parseBlock(line string) (Block, bool) {
+	location, counts, found := strings.Cut(line, " ")
+	separator := strings.LastIndex(location, ":")
+	if !found || separator < 0 {
+		return Block{}, false
+	}
+
+	fields := strings.Fields(counts)
+	start, end, found := strings.Cut(location[separator+1:], ",")
+	if len(fields) != 2 || !found {
+		return Block{}, false
+	}
+
+	startLine, err1 := strconv.Atoi(strings.Split(start, ".")[0])
+	endLine, err2 := strconv.Atoi(strings.Split(end, ".")[0])
+	statements, err3 := strconv.Atoi(fields[0])
+	count, err4 := strconv.Atoi(fields[1])
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return Block{}, false
+	}
+
+	return Block{
+		File:       location[:separator],
+		StartLine:  startLine,
+		EndLine:    endLine,
+		Statements: statements,
+		Covered:    count > 0,
+	}, true
+}
+
+// Gaps retorna as funções do arquivo que não estão totalmente cobertas, com as linhas sem cobertura
+func (c *Coverage) Gaps(file string) ([]Gap, error) {
+	functions, err := source.GoFunctions(file)
+	if err != nil {
+		return nil, err
+	}
+
+	// O perfil identifica os arquivos pelo caminho de importação, então basta comparar o nome
+	name := filepath.Base(file)
+	blocks := []Block{}
+	for _, block := range c.Blocks {
+		if filepath.Base(block.File) == name {
+			blocks = append(blocks, block)
+		}
+	}
+
+	gaps := []Gap{}
+	for _, function := range functions {
+		gap := Gap{Function: function.Name, Uncovered: true, Lines: []string{}}
+		found := false
+		for _, block := range blocks {
+			if block.StartLine < function.StartLine || block.EndLine > function.EndLine {
+				continue
+			}
+			found = true
+			if block.Covered {
+				gap.Uncovered = false
+				continue
+			}
+			lines := strconv.Itoa(block.StartLine)
+			if block.EndLine != block.StartLine {
+				lines += "-" + strconv.Itoa(block.EndLine)
+			}
+			gap.Lines = append(gap.Lines, lines)
+		}
+		if found && len(gap.Lines) > 0 {
+			gaps = append(gaps, gap)
+		}
+	}
+	return gaps, nil
+}
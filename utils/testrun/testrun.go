@@ -0,0 +1,309 @@
+package testrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"gojira/utils/i18n"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// timeout limita o tempo de uma execução de testes
+const timeout = 5 * time.Minute
+
+// Result representa o resultado da execução de um arquivo de testes
+type Result struct {
+	Passed  bool     `json:"passed"`
+	Passing []string `json:"passing"`
+	Failing []string `json:"failing"`
+	Output  string   `json:"-"`
+}
+
+// Runner executa os testes de um arquivo em uma linguagem específica
+type Runner interface {
+	// Name retorna o nome da ferramenta de testes
+	Name() string
+
+	// Available verifica se a ferramenta de testes está instalada para o arquivo
+	Available(testFile string) error
+
+	// Run executa os testes do arquivo e retorna os casos que passaram e falharam
+	Run(testFile string) (*Result, error)
+
+	// TestNames retorna os nomes dos casos de teste definidos no código
+	TestNames(code string) []string
+}
+
+// runners associa as linguagens às ferramentas de teste suportadas
+var runners = map[string]func() Runner{
+	"Go":         func() Runner { return &goRunner{} },
+	"Python":     func() Runner { return &pytestRunner{} },
+	"JavaScript": func() Runner { return &jestRunner{} },
+	"TypeScript": func() Runner { return &jestRunner{} },
+}
+
+// For retorna o executor de testes da linguagem, se houver um
+func For(language string) (Runner, bool) {
+	factory, exists := runners[language]
+	if !exists {
+		return nil, false
+	}
+	return factory(), true
+}
+
+// run executa um comando no diretório com o tempo limite e retorna a saída combinada.
+// Um código de saída diferente de zero não é um erro: significa que os testes falharam.
+func run(dir string, name string, args ...string) (string, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return string(output), false, nil
+	}
+	if ctx.Err() != nil {
+		return string(output), false, i18n.Errorf("testrun.timeout", name, timeout)
+	}
+	if err != nil {
+		return "", false, i18n.Errorf("testrun.exec_error", name, err)
+	}
+	return string(output), true, nil
+}
+
+// matches retorna o primeiro grupo de cada ocorrência da expressão, sem repetições
+func matches(expression *regexp.Regexp, text string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, match := range expression.FindAllStringSubmatch(text, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// goRunner executa testes Go com go test
+type goRunner struct{}
+
+var (
+	goTestPattern = regexp.MustCompile(`(?m)^func\s+(Test\w*)\s*\(\s*\w+\s+\*testing\.T\s*\)`)
+	goPassPattern = regexp.MustCompile(`(?m)^\s*--- PASS: (\S+)`)
+	goFailPattern = regexp.MustCompile(`(?m)^\s*--- FAIL: (\S+)`)
+)
+
+// Name retorna o nome da ferramenta de testes
+func (r *goRunner) Name() string {
+	return "go test"
+}
+
+// TestNames retorna as funções de teste do arquivo
+func (r *goRunner) TestNames(code string) []string {
+	return matches(goTestPattern, code)
+}
+
+// Available verifica se o go está no PATH
+func (r *goRunner) Available(testFile string) error {
+	if _, err := exec.LookPath("go"); err != nil {
+		return i18n.Errorf("testrun.not_installed", "go")
+	}
+	return nil
+}
+
+// Run executa apenas os testes do arquivo, no pacote em que ele está
+func (r *goRunner) Run(testFile string) (*Result, error) {
+	args := []string{"test", "-v", "-count=1"}
+	if content, err := os.ReadFile(testFile); err == nil {
+		if names := r.TestNames(string(content)); len(names) > 0 {
+			args = append(args, "-run", "^("+strings.Join(names, "|")+")$")
+		}
+	}
+	args = append(args, ".")
+
+	output, passed, err := run(filepath.Dir(testFile), "go", args...)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Passed:  passed,
+		Passing: topLevel(matches(goPassPattern, output)),
+		Failing: topLevel(matches(goFailPattern, output)),
+		Output:  output,
+	}, nil
+}
+
+// topLevel descarta os subtestes (Teste/caso), mantendo apenas as funções de teste
+func topLevel(names []string) []string {
+	result := []string{}
+	for _, name := range names {
+		if !strings.Contains(name, "/") {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// pytestRunner executa testes Python com pytest
+type pytestRunner struct{}
+
+var (
+	pytestTestPattern   = regexp.MustCompile(`(?m)^\s*(?:async\s+)?def\s+(test\w*)\s*\(`)
+	pytestResultPattern = regexp.MustCompile(`(?m)::(\S+?)(?:\[[^\]]*\])?\s+(PASSED|FAILED|ERROR)`)
+)
+
+// Name retorna o nome da ferramenta de testes
+func (r *pytestRunner) Name() string {
+	return "pytest"
+}
+
+// TestNames retorna as funções de teste do arquivo
+func (r *pytestRunner) TestNames(code string) []string {
+	return matches(pytestTestPattern, code)
+}
+
+// Available verifica se o pytest está instalado no Python do PATH
+func (r *pytestRunner) Available(testFile string) error {
+	if _, err := exec.LookPath("python3"); err != nil {
+		return i18n.Errorf("testrun.not_installed", "python3")
+	}
+	if err := exec.Command("python3", "-m", "pytest", "--version").Run(); err != nil {
+		return i18n.Errorf("testrun.not_installed", "pytest")
+	}
+	return nil
+}
+
+// Run executa o arquivo com pytest em modo detalhado
+func (r *pytestRunner) Run(testFile string) (*Result, error) {
+	output, passed, err := run(filepath.Dir(testFile), "python3", "-m", "pytest", "-v", filepath.Base(testFile))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Passed: passed, Passing: []string{}, Failing: []string{}, Output: output}
+	for _, match := range pytestResultPattern.FindAllStringSubmatch(output, -1) {
+		name := match[1]
+		if index := strings.LastIndex(name, "::"); index >= 0 {
+			name = name[index+2:]
+		}
+		if match[2] == "PASSED" {
+			result.Passing = append(result.Passing, name)
+		} else {
+			result.Failing = append(result.Failing, name)
+		}
+	}
+	return result, nil
+}
+
+// jestRunner executa testes JavaScript e TypeScript com Jest
+type jestRunner struct{}
+
+var jestTestPattern = regexp.MustCompile("(?m)\\b(?:it|test)\\s*\\(\\s*['\"`](.+?)['\"`]")
+
+// Name retorna o nome da ferramenta de testes
+func (r *jestRunner) Name() string {
+	return "jest"
+}
+
+// TestNames retorna os títulos dos casos de teste do arquivo
+func (r *jestRunner) TestNames(code string) []string {
+	return matches(jestTestPattern, code)
+}
+
+// Available verifica se o projeto tem o Jest instalado
+func (r *jestRunner) Available(testFile string) error {
+	if localJest(testFile) == "" {
+		return errors.New(i18n.T("testrun.jest_missing"))
+	}
+	return nil
+}
+
+// Run executa o arquivo com o Jest do projeto, lendo o relatório JSON. O Jest nunca é baixado:
+// sem ele em node_modules, a execução falha.
+func (r *jestRunner) Run(testFile string) (*Result, error) {
+	jest := localJest(testFile)
+	if jest == "" {
+		return nil, errors.New(i18n.T("testrun.jest_missing"))
+	}
+	output, passed, err := run(filepath.Dir(testFile), jest, "--json", filepath.Base(testFile))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Passed: passed, Passing: []string{}, Failing: []string{}, Output: output}
+
+	// O relatório JSON vem misturado às mensagens do Jest e começa pelos contadores
+	var report struct {
+		TestResults []struct {
+			Message          string `json:"message"`
+			AssertionResults []struct {
+				Title  string `json:"title"`
+				Status string `json:"status"`
+			} `json:"assertionResults"`
+		} `json:"testResults"`
+	}
+	if index := strings.Index(output, `{"num`); index >= 0 && json.Unmarshal([]byte(output[index:]), &report) == nil {
+		messages := []string{}
+		for _, file := range report.TestResults {
+			for _, assertion := range file.AssertionResults {
+				if assertion.Status == "passed" {
+					result.Passing = append(result.Passing, assertion.Title)
+				} else if assertion.Status == "failed" {
+					result.Failing = append(result.Failing, assertion.Title)
+				}
+			}
+			if file.Message != "" {
+				messages = append(messages, file.Message)
+			}
+		}
+		if len(messages) > 0 {
+			result.Output = strings.Join(messages, "\n")
+		}
+	}
+	return result, nil
+}
+
+// localJest procura o executável do Jest em node_modules/.bin, do diretório do arquivo até a raiz
+func localJest(testFile string) string {
+	dir, err := filepath.Abs(filepath.Dir(testFile))
+	if err != nil {
+		return ""
+	}
+	for {
+		candidate := filepath.Join(dir, "node_modules", ".bin", "jest")
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Removed retorna os casos presentes em before que não estão em after, em ordem alfabética
+func Removed(runner Runner, before, after string) []string {
+	kept := map[string]bool{}
+	for _, name := range runner.TestNames(after) {
+		kept[name] = true
+	}
+
+	removed := []string{}
+	for _, name := range runner.TestNames(before) {
+		if !kept[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}